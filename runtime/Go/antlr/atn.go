@@ -4,6 +4,8 @@
 
 package antlr
 
+import "sync"
+
 var ATNInvalidAltNumber int
 
 type ATN struct {
@@ -37,6 +39,11 @@ type ATN struct {
 	ruleToTokenType []int
 
 	states []ATNState
+
+	// mu guards the NextTokenWithinRule cache of the states, the only part of
+	// the ATN that changes after deserialization. Lookups of cached sets only
+	// take its read lock.
+	mu sync.RWMutex
 }
 
 func NewATN(grammarType int, maxTokenType int) *ATN {
@@ -59,14 +66,24 @@ func (a *ATN) NextTokensInContext(s ATNState, ctx RuleContext) *IntervalSet {
 // in s and staying in same rule. Token.EPSILON is in set if we reach end of
 // rule.
 func (a *ATN) NextTokensNoContext(s ATNState) *IntervalSet {
-	if s.GetNextTokenWithinRule() != nil {
-		return s.GetNextTokenWithinRule()
+	a.mu.RLock()
+	next := s.GetNextTokenWithinRule()
+	a.mu.RUnlock()
+	if next != nil {
+		return next
 	}
 
-	s.SetNextTokenWithinRule(a.NextTokensInContext(s, nil))
-	s.GetNextTokenWithinRule().readOnly = true
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// another goroutine may have computed the set in the meantime
+	if next = s.GetNextTokenWithinRule(); next != nil {
+		return next
+	}
+	next = a.NextTokensInContext(s, nil)
+	next.readOnly = true
+	s.SetNextTokenWithinRule(next)
 
-	return s.GetNextTokenWithinRule()
+	return next
 }
 
 func (a *ATN) NextTokens(s ATNState, ctx RuleContext) *IntervalSet {
@@ -77,6 +94,84 @@ func (a *ATN) NextTokens(s ATNState, ctx RuleContext) *IntervalSet {
 	return a.NextTokensInContext(s, ctx)
 }
 
+// RulesStartingWith returns, in increasing order, the indexes of the rules
+// whose first set contains tokenType, that is the rules whose input may
+// begin with a token of that type. Only the tokens matched within each rule
+// are considered: a rule that can match the empty input does not start with
+// the tokens that follow it.
+func (a *ATN) RulesStartingWith(tokenType int) []int {
+	rules := make([]int, 0)
+	for i, s := range a.ruleToStartState {
+		if a.NextTokens(s, nil).contains(tokenType) {
+			rules = append(rules, i)
+		}
+	}
+	return rules
+}
+
+// UnusedTokenTypes returns, in increasing order, the token types
+// 1..maxTokenType that no transition of the ATN matches explicitly, that is
+// the tokens a grammar declares but none of its rules references. Wildcards
+// are not taken into account.
+func (a *ATN) UnusedTokenTypes(maxTokenType int) []int {
+	used := NewIntervalSet()
+	for _, s := range a.states {
+		if s == nil {
+			continue
+		}
+		for _, t := range s.GetTransitions() {
+			if label := t.getLabel(); label != nil {
+				used.addSet(label)
+			}
+		}
+	}
+	unused := make([]int, 0)
+	for tokenType := TokenMinUserTokenType; tokenType <= maxTokenType; tokenType++ {
+		if !used.contains(tokenType) {
+			unused = append(unused, tokenType)
+		}
+	}
+	return unused
+}
+
+// PossibleDescendantRules returns, in increasing order, the indexes of the
+// rules that may appear in the subtree of a ruleIndex node, following the
+// calls between rules transitively. ruleIndex itself is included only if the
+// rule is recursive. Rules are assumed to be reachable whenever they are
+// called, predicates are not evaluated.
+func (a *ATN) PossibleDescendantRules(ruleIndex int) []int {
+	calls := make([][]int, len(a.ruleToStartState))
+	for _, s := range a.states {
+		if s == nil {
+			continue
+		}
+		for _, t := range s.GetTransitions() {
+			if rt, ok := t.(*RuleTransition); ok {
+				calls[s.GetRuleIndex()] = append(calls[s.GetRuleIndex()], rt.ruleIndex)
+			}
+		}
+	}
+
+	reached := make([]bool, len(calls))
+	work := append([]int(nil), calls[ruleIndex]...)
+	for len(work) > 0 {
+		r := work[len(work)-1]
+		work = work[:len(work)-1]
+		if !reached[r] {
+			reached[r] = true
+			work = append(work, calls[r]...)
+		}
+	}
+
+	rules := make([]int, 0)
+	for r, ok := range reached {
+		if ok {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
 func (a *ATN) addState(state ATNState) {
 	if state != nil {
 		state.SetATN(a)
@@ -0,0 +1,224 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// ATNMaxKUnbounded is returned by EstimateMaxK for decisions that no
+	// fixed lookahead up to ATNMaxKLimit can make.
+	ATNMaxKUnbounded = -1
+
+	// ATNMaxKLimit is the largest lookahead EstimateMaxK tries.
+	ATNMaxKLimit = 4
+
+	// atnMaxKMaxPaths bounds the number of lookahead sequences collected
+	// for a decision, beyond which it is considered unbounded.
+	atnMaxKMaxPaths = 2000
+
+	// atnMaxKMaxDepth bounds the number of rule invocations followed
+	// without matching a token.
+	atnMaxKMaxDepth = 64
+)
+
+// EstimateMaxK returns the smallest k such that the next k tokens always
+// tell the alternatives of decision apart, as in an LL(k) parser, or
+// ATNMaxKUnbounded if no k up to ATNMaxKLimit does. The analysis is
+// static: semantic and precedence predicates are ignored and a rule may be
+// followed by anything that follows any of its invocations, so the estimate
+// may be larger than what the adaptive prediction needs in practice. The
+// decisions of left recursive rules, which predicates resolve, are usually
+// unbounded.
+func (a *ATN) EstimateMaxK(decision int) int {
+	s := a.DecisionToState[decision]
+	for k := 1; k <= ATNMaxKLimit; k++ {
+		e := &lookaheadExplorer{atn: a, k: k}
+		alts := make([][][]*IntervalSet, 0, len(s.GetTransitions()))
+		for _, t := range s.GetTransitions() {
+			e.paths = nil
+			e.explore(t.getTarget(), nil, nil, make(map[string]bool))
+			if e.overflow {
+				return ATNMaxKUnbounded
+			}
+			alts = append(alts, e.paths)
+		}
+		if !lookaheadConflicts(alts) {
+			return k
+		}
+	}
+	return ATNMaxKUnbounded
+}
+
+// lookaheadExplorer collects the sequences of up to k token sets that can be
+// matched from an ATN state. A sequence is shorter than k only if it ends
+// with EOF.
+type lookaheadExplorer struct {
+	atn      *ATN
+	k        int
+	paths    [][]*IntervalSet
+	overflow bool
+}
+
+// explore follows the transitions of s with the follow states of the rules
+// entered so far on stack and the token sets matched so far in seq. busy holds
+// the configurations reached since the last token was matched and stops
+// epsilon cycles.
+func (e *lookaheadExplorer) explore(s ATNState, stack []ATNState, seq []*IntervalSet, busy map[string]bool) {
+	if e.overflow {
+		return
+	}
+	if len(seq) == e.k {
+		e.record(seq)
+		return
+	}
+	if len(stack) > atnMaxKMaxDepth {
+		e.overflow = true
+		return
+	}
+	key := lookaheadKey(s, stack)
+	if busy[key] {
+		return
+	}
+	busy[key] = true
+
+	if _, ok := s.(*RuleStopState); ok {
+		if len(stack) > 0 {
+			e.explore(stack[len(stack)-1], stack[:len(stack)-1], seq, busy)
+			return
+		}
+		if len(s.GetTransitions()) == 0 {
+			eof := NewIntervalSet()
+			eof.addOne(TokenEOF)
+			e.record(append(seq[:len(seq):len(seq)], eof))
+			return
+		}
+		// outside of any known invocation, continue after all of them
+	}
+
+	for _, t := range s.GetTransitions() {
+		switch tt := t.(type) {
+		case *RuleTransition:
+			next := make([]ATNState, len(stack), len(stack)+1)
+			copy(next, stack)
+			e.explore(tt.getTarget(), append(next, tt.followState), seq, busy)
+		case *NotSetTransition:
+			e.match(tt, tt.getLabel().Complement(TokenMinUserTokenType, e.atn.maxTokenType), stack, seq)
+		case *WildcardTransition:
+			all := NewIntervalSet()
+			all.addRange(TokenMinUserTokenType, e.atn.maxTokenType)
+			e.match(tt, all, stack, seq)
+		default:
+			if t.getIsEpsilon() {
+				e.explore(t.getTarget(), stack, seq, busy)
+			} else if label := t.getLabel(); label != nil {
+				e.match(t, label, stack, seq)
+			}
+		}
+	}
+}
+
+func (e *lookaheadExplorer) match(t Transition, set *IntervalSet, stack []ATNState, seq []*IntervalSet) {
+	e.explore(t.getTarget(), stack, append(seq[:len(seq):len(seq)], set), make(map[string]bool))
+}
+
+func (e *lookaheadExplorer) record(seq []*IntervalSet) {
+	e.paths = append(e.paths, seq)
+	if len(e.paths) > atnMaxKMaxPaths {
+		e.overflow = true
+	}
+}
+
+func lookaheadKey(s ATNState, stack []ATNState) string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(s.GetStateNumber()))
+	for _, f := range stack {
+		b.WriteByte(' ')
+		b.WriteString(strconv.Itoa(f.GetStateNumber()))
+	}
+	return b.String()
+}
+
+// lookaheadConflicts reports whether a sequence of one alternative may match
+// the same tokens as a sequence of another one.
+func lookaheadConflicts(alts [][][]*IntervalSet) bool {
+	for i := range alts {
+		for j := i + 1; j < len(alts); j++ {
+			for _, p := range alts[i] {
+				for _, q := range alts[j] {
+					if lookaheadOverlaps(p, q) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func lookaheadOverlaps(p, q []*IntervalSet) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i].And(q[i]).length() == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PredicatedAlternatives returns the alternatives of decision, in increasing
+// order, that may evaluate a semantic or precedence predicate before
+// matching their first token. Only these alternatives can be turned off by a
+// predicate when the decision is predicted; the others are chosen on
+// lookahead alone. Rules entered before the first token are followed, and a
+// rule end reached that way continues after all invocations of the rule.
+func (a *ATN) PredicatedAlternatives(decision int) []int {
+	alts := make([]int, 0)
+	for i, t := range a.DecisionToState[decision].GetTransitions() {
+		if predicatedPath(t.getTarget(), nil, make(map[string]bool)) {
+			alts = append(alts, i+1)
+		}
+	}
+	return alts
+}
+
+// predicatedPath reports whether a predicate transition can be reached from
+// s without matching a token, with the follow states of the rules entered so
+// far on stack.
+func predicatedPath(s ATNState, stack []ATNState, busy map[string]bool) bool {
+	if len(stack) > atnMaxKMaxDepth {
+		return false
+	}
+	key := lookaheadKey(s, stack)
+	if busy[key] {
+		return false
+	}
+	busy[key] = true
+
+	if _, ok := s.(*RuleStopState); ok && len(stack) > 0 {
+		return predicatedPath(stack[len(stack)-1], stack[:len(stack)-1], busy)
+	}
+	for _, t := range s.GetTransitions() {
+		switch tt := t.(type) {
+		case *PredicateTransition, *PrecedencePredicateTransition:
+			return true
+		case *RuleTransition:
+			next := make([]ATNState, len(stack), len(stack)+1)
+			copy(next, stack)
+			if predicatedPath(tt.getTarget(), append(next, tt.followState), busy) {
+				return true
+			}
+		default:
+			if t.getIsEpsilon() && predicatedPath(t.getTarget(), stack, busy) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestATNRulesStartingWith(t *testing.T) {
+	assert := assertNew(t)
+	atn := NewExprParser(nil).GetATN()
+
+	assert.Equal([]int{ExprParserRULE_stat}, atn.RulesStartingWith(ExprParserRETURN))
+	assert.Equal([]int{ExprParserRULE_prog, ExprParserRULE_func_}, atn.RulesStartingWith(ExprParserT__0))
+	assert.Equal([]int{ExprParserRULE_arg, ExprParserRULE_stat, ExprParserRULE_expr, ExprParserRULE_primary}, atn.RulesStartingWith(ExprParserID))
+	assert.Equal([]int{ExprParserRULE_stat, ExprParserRULE_expr, ExprParserRULE_primary}, atn.RulesStartingWith(ExprParserINT))
+	assert.Equal([]int{}, atn.RulesStartingWith(ExprParserMUL))
+	assert.Equal([]int{}, atn.RulesStartingWith(TokenEOF))
+}
+
+func TestATNUnusedTokenTypes(t *testing.T) {
+	assert := assertNew(t)
+	atn := NewExprParser(nil).GetATN()
+
+	// NEWLINE and WS are skipped by the lexer and never referenced by a rule
+	assert.Equal([]int{ExprParserNEWLINE, ExprParserWS}, atn.UnusedTokenTypes(ExprParserWS))
+	assert.Equal([]int{ExprParserNEWLINE, ExprParserWS, ExprParserWS + 1}, atn.UnusedTokenTypes(ExprParserWS+1))
+	assert.Equal([]int{}, atn.UnusedTokenTypes(ExprParserINT))
+	assert.Equal([]int{}, atn.UnusedTokenTypes(0))
+}
+
+func TestATNEstimateMaxK(t *testing.T) {
+	assert := assertNew(t)
+	atn := NewExprParser(nil).GetATN()
+
+	// (func_)+ in prog: 'def' continues, anything else ends the loop
+	assert.Equal(1, atn.EstimateMaxK(0))
+	// (',' arg)* in func_
+	assert.Equal(1, atn.EstimateMaxK(1))
+	// stat: expr ';' | ID '=' expr ';' | ... needs the token after ID
+	assert.Equal(2, atn.EstimateMaxK(3))
+	// the operator loop of expr is resolved by precedence predicates
+	assert.Equal(ATNMaxKUnbounded, atn.EstimateMaxK(5))
+}
+
+func TestATNPredicatedAlternatives(t *testing.T) {
+	assert := assertNew(t)
+
+	// s : {$parser.allow}? ID | ID ;
+	assert.Equal([]int{1}, newPredParser(nil).GetATN().PredicatedAlternatives(0))
+
+	atn := NewExprParser(nil).GetATN()
+	assert.Equal([]int{}, atn.PredicatedAlternatives(3))
+	// every operator alternative of expr checks its precedence
+	assert.Equal([]int{1, 2}, atn.PredicatedAlternatives(4))
+}
+
+func TestATNPossibleDescendantRules(t *testing.T) {
+	assert := assertNew(t)
+	atn := NewExprParser(nil).GetATN()
+
+	assert.Equal([]int{ExprParserRULE_func_, ExprParserRULE_body, ExprParserRULE_arg, ExprParserRULE_stat, ExprParserRULE_expr, ExprParserRULE_primary},
+		atn.PossibleDescendantRules(ExprParserRULE_prog))
+	// a statement contains expressions, which contain themselves
+	assert.Equal([]int{ExprParserRULE_expr, ExprParserRULE_primary}, atn.PossibleDescendantRules(ExprParserRULE_stat))
+	assert.Equal([]int{ExprParserRULE_expr, ExprParserRULE_primary}, atn.PossibleDescendantRules(ExprParserRULE_expr))
+	assert.Equal([]int{}, atn.PossibleDescendantRules(ExprParserRULE_arg))
+}
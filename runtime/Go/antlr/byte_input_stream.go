@@ -0,0 +1,158 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// ByteInputStream is a CharStream over a UTF-8 encoded byte slice that
+// decodes characters as they are looked at, so a large buffer can be lexed
+// without converting it to a string or runes first.
+//
+// Positions in the stream are byte offsets into the slice: Index, Seek,
+// Size and GetText, and so the start and stop of the tokens lexed from the
+// stream, count bytes, while LA and Consume work on whole characters and
+// lexers still count columns in characters. Seek must be given the offset
+// of the first byte of a character, such as the start of a token.
+//
+// Each byte of an invalid UTF-8 sequence reads as utf8.RuneError, which no
+// lexer rule matches unless the grammar asks for U+FFFD, so lexers report a
+// token recognition error at the offending byte through their error
+// listeners. Err reports the offset of the first such byte.
+type ByteInputStream struct {
+	name  string
+	data  []byte
+	index int
+	err   error
+}
+
+// NewInputStreamFromBytes creates a stream of the UTF-8 encoded characters
+// of b. b is not copied and must not be modified while the stream is in
+// use.
+func NewInputStreamFromBytes(b []byte) *ByteInputStream {
+	return &ByteInputStream{
+		name: "<empty>",
+		data: b,
+	}
+}
+
+// Err returns the error describing the first invalid UTF-8 encoding read
+// from the stream, or nil if none was found so far.
+func (s *ByteInputStream) Err() error {
+	return s.err
+}
+
+// decode returns the character at byte offset pos and its size in bytes, or
+// TokenEOF and 0 at the end of the input. An invalid encoding decodes as
+// utf8.RuneError of size 1.
+func (s *ByteInputStream) decode(pos int) (int, int) {
+	if pos >= len(s.data) {
+		return TokenEOF, 0
+	}
+	c, size := utf8.DecodeRune(s.data[pos:])
+	if c == utf8.RuneError && size == 1 && s.err == nil {
+		s.err = fmt.Errorf("invalid UTF-8 encoding at byte offset %d", pos)
+	}
+	return int(c), size
+}
+
+func (s *ByteInputStream) Consume() {
+	_, size := s.decode(s.index)
+	if size == 0 {
+		panic("cannot consume EOF")
+	}
+	s.index += size
+}
+
+func (s *ByteInputStream) LA(offset int) int {
+	if offset == 0 {
+		return 0
+	}
+	pos := s.index
+	if offset < 0 {
+		for ; offset < 0; offset++ {
+			if pos <= 0 {
+				return TokenEOF
+			}
+			_, size := utf8.DecodeLastRune(s.data[:pos])
+			pos -= size
+		}
+		c, _ := s.decode(pos)
+		return c
+	}
+	for ; offset > 1; offset-- {
+		_, size := s.decode(pos)
+		if size == 0 {
+			return TokenEOF
+		}
+		pos += size
+	}
+	c, _ := s.decode(pos)
+	return c
+}
+
+func (s *ByteInputStream) LT(offset int) int {
+	return s.LA(offset)
+}
+
+func (s *ByteInputStream) Index() int {
+	return s.index
+}
+
+// Size returns the number of bytes of the input.
+func (s *ByteInputStream) Size() int {
+	return len(s.data)
+}
+
+// mark/release do nothing, the whole input is available
+func (s *ByteInputStream) Mark() int {
+	return -1
+}
+
+func (s *ByteInputStream) Release(marker int) {
+}
+
+func (s *ByteInputStream) Seek(index int) {
+	s.index = intMin(index, len(s.data))
+}
+
+// GetText returns the characters encoded in the bytes start..stop, both
+// included.
+func (s *ByteInputStream) GetText(start int, stop int) string {
+	if stop >= len(s.data) {
+		stop = len(s.data) - 1
+	}
+	if start >= len(s.data) || stop < start {
+		return ""
+	}
+	return string(s.data[start : stop+1])
+}
+
+func (s *ByteInputStream) GetTextFromTokens(start, stop Token) string {
+	if start != nil && stop != nil {
+		return s.GetText(start.GetStart(), stop.GetStop())
+	}
+
+	return ""
+}
+
+func (s *ByteInputStream) GetTextFromInterval(i *Interval) string {
+	return s.GetText(i.Start, i.Stop)
+}
+
+// SetSourceName sets the name returned by GetSourceName.
+func (s *ByteInputStream) SetSourceName(name string) {
+	s.name = name
+}
+
+func (s *ByteInputStream) GetSourceName() string {
+	return s.name
+}
+
+func (s *ByteInputStream) String() string {
+	return string(s.data)
+}
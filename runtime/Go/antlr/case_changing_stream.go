@@ -0,0 +1,38 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"unicode"
+)
+
+// caseChangingStream wraps a CharStream so that LA returns case-folded
+// characters while all text is still read from the wrapped stream.
+type caseChangingStream struct {
+	CharStream
+
+	upper bool
+}
+
+// NewCaseChangingCharStream returns a CharStream whose LA returns the upper
+// case (if upper is true) or lower case form of each character of stream.
+// A lexer reading from it matches keywords written as upper or lower case
+// in the grammar regardless of how they are written in the input, while
+// GetText, and so the text of every token, keeps the original casing.
+// Characters without a case mapping are returned unchanged.
+func NewCaseChangingCharStream(stream CharStream, upper bool) CharStream {
+	return &caseChangingStream{CharStream: stream, upper: upper}
+}
+
+func (is *caseChangingStream) LA(offset int) int {
+	c := is.CharStream.LA(offset)
+	if c <= 0 {
+		return c
+	}
+	if is.upper {
+		return int(unicode.ToUpper(rune(c)))
+	}
+	return int(unicode.ToLower(rune(c)))
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestCaseChangingCharStream(t *testing.T) {
+	assert := assertNew(t)
+	l := NewExprLexer(NewCaseChangingCharStream(NewInputStream("RETURN return Return x"), false))
+
+	tokens := l.GetAllTokens()
+	assert.Equal([]int{ExprLexerRETURN, ExprLexerRETURN, ExprLexerRETURN, ExprLexerID}, lexerTestTokenTypes(tokens))
+	assert.Equal("RETURN", tokens[0].GetText())
+	assert.Equal("return", tokens[1].GetText())
+	assert.Equal("Return", tokens[2].GetText())
+}
+
+func TestCaseChangingCharStreamLA(t *testing.T) {
+	assert := assertNew(t)
+	input := NewInputStream("aÉ日ß1")
+
+	upper := NewCaseChangingCharStream(input, true)
+	assert.Equal([]int{'A', 'É', '日', 'ß', '1', TokenEOF}, []int{upper.LA(1), upper.LA(2), upper.LA(3), upper.LA(4), upper.LA(5), upper.LA(6)})
+
+	lower := NewCaseChangingCharStream(input, false)
+	assert.Equal([]int{'a', 'é', '日', 'ß', '1'}, []int{lower.LA(1), lower.LA(2), lower.LA(3), lower.LA(4), lower.LA(5)})
+
+	lower.Consume()
+	assert.Equal('é', rune(lower.LA(1)))
+	assert.Equal("aÉ日", lower.GetText(0, 2))
+	assert.Equal(1, input.Index())
+}
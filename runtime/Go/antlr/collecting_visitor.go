@@ -0,0 +1,76 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// CollectingVisitor is a ParseTreeVisitor whose Visit returns a
+// []interface{} gathering the results of all terminals below the visited
+// node, in document order. A rule node aggregates the results of its
+// children by appending them; the result of a terminal comes from
+// OnTerminal, and that of an error node from OnErrorNode. A nil hook, or a
+// hook returning nil, contributes nothing.
+//
+// If ShouldVisitNode is set, it is asked before the visitor descends into a
+// rule node. When it returns false the subtree of the node is skipped
+// entirely, no hook is called for anything in it, and the node contributes
+// nothing to the result.
+type CollectingVisitor struct {
+	*BaseParseTreeVisitor
+
+	OnTerminal      func(node TerminalNode) interface{}
+	OnErrorNode     func(node ErrorNode) interface{}
+	ShouldVisitNode func(node RuleNode) bool
+}
+
+// NewCollectingVisitor creates a visitor collecting the results of
+// onTerminal. Set OnErrorNode to also collect results from error nodes.
+func NewCollectingVisitor(onTerminal func(node TerminalNode) interface{}) *CollectingVisitor {
+	return &CollectingVisitor{
+		BaseParseTreeVisitor: &BaseParseTreeVisitor{},
+		OnTerminal:           onTerminal,
+	}
+}
+
+// Collect visits tree and returns the collected results, which is never nil.
+func (v *CollectingVisitor) Collect(tree ParseTree) []interface{} {
+	return v.Visit(tree).([]interface{})
+}
+
+func (v *CollectingVisitor) Visit(tree ParseTree) interface{} {
+	return tree.Accept(v)
+}
+
+func (v *CollectingVisitor) VisitChildren(node RuleNode) interface{} {
+	results := make([]interface{}, 0)
+	if v.ShouldVisitNode != nil && !v.ShouldVisitNode(node) {
+		return results
+	}
+	for _, child := range node.GetChildren() {
+		if r, ok := child.(ParseTree).Accept(v).([]interface{}); ok {
+			results = append(results, r...)
+		}
+	}
+	return results
+}
+
+func (v *CollectingVisitor) VisitTerminal(node TerminalNode) interface{} {
+	if v.OnTerminal == nil {
+		return make([]interface{}, 0)
+	}
+	return collectingVisitorResult(v.OnTerminal(node))
+}
+
+func (v *CollectingVisitor) VisitErrorNode(node ErrorNode) interface{} {
+	if v.OnErrorNode == nil {
+		return make([]interface{}, 0)
+	}
+	return collectingVisitorResult(v.OnErrorNode(node))
+}
+
+func collectingVisitorResult(r interface{}) []interface{} {
+	if r == nil {
+		return make([]interface{}, 0)
+	}
+	return []interface{}{r}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func collectingVisitorTestIdentifiers(node TerminalNode) interface{} {
+	if node.GetSymbol().GetTokenType() == ExprLexerID {
+		return node.GetText()
+	}
+	return nil
+}
+
+func TestCollectingVisitor(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(x) { y = x*2; return y; }\ndef g(a, b) { a+b; }").Prog()
+
+	v := NewCollectingVisitor(collectingVisitorTestIdentifiers)
+	assert.Equal([]interface{}{"f", "x", "y", "x", "y", "g", "a", "b", "a", "b"}, v.Collect(tree))
+
+	body := TreesfindAllRuleNodes(tree, ExprParserRULE_body)[1]
+	assert.Equal([]interface{}{"a", "b"}, v.Visit(body))
+	assert.Equal([]interface{}{}, v.Visit(TreesFindAllTokenNodes(body, ExprLexerT__6)[0]))
+
+	assert.Equal(0, len(NewCollectingVisitor(nil).Collect(tree)))
+}
+
+func TestCollectingVisitorErrorNodes(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x = 1 2; }")
+	p.RemoveErrorListeners()
+	tree := p.Prog()
+
+	v := NewCollectingVisitor(collectingVisitorTestIdentifiers)
+	assert.Equal([]interface{}{"f", "x", "x"}, v.Collect(tree))
+
+	v.OnErrorNode = func(node ErrorNode) interface{} {
+		return "error " + node.GetText()
+	}
+	assert.Equal([]interface{}{"f", "x", "x", "error 2"}, v.Collect(tree))
+}
+
+func TestCollectingVisitorShouldVisitNode(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(x) { y = x*2; return y; }\ndef g(a, b) { a+b; }").Prog()
+
+	var visited []string
+	v := NewCollectingVisitor(func(node TerminalNode) interface{} {
+		visited = append(visited, node.GetText())
+		return collectingVisitorTestIdentifiers(node)
+	})
+	var asked []int
+	v.ShouldVisitNode = func(node RuleNode) bool {
+		ruleIndex := node.GetRuleContext().GetRuleIndex()
+		asked = append(asked, ruleIndex)
+		return ruleIndex != ExprParserRULE_body
+	}
+
+	assert.Equal([]interface{}{"f", "x", "g", "a", "b"}, v.Collect(tree))
+	assert.Equal([]string{"def", "f", "(", "x", ")", "def", "g", "(", "a", ",", "b", ")"}, visited)
+	for _, ruleIndex := range asked {
+		assert.Equal(false, ruleIndex == ExprParserRULE_stat || ruleIndex == ExprParserRULE_expr)
+	}
+
+	// pruning the visited node itself
+	v.ShouldVisitNode = func(node RuleNode) bool { return false }
+	assert.Equal([]interface{}{}, v.Collect(tree))
+}
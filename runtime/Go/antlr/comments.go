@@ -0,0 +1,73 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// CommentInfo describes a comment token found by ExtractComments.
+type CommentInfo struct {
+	Text   string
+	Line   int
+	Column int
+
+	// Node is the rule node that follows the comment, or nil if only EOF
+	// follows it.
+	Node ParserRuleContext
+}
+
+// ExtractComments returns every token of stream on commentChannel, in input
+// order, together with the rule node of tree that follows it.
+//
+// The following node is found from the first token after the comment on the
+// channel stream parses. Of the rule nodes starting with that token, the
+// innermost one spanning more than that single token is used, so a comment
+// before "x = 1;" is attached to the statement rather than to the
+// expression x. tree must have been parsed from stream.
+func ExtractComments(stream *CommonTokenStream, commentChannel int, tree ParseTree) []CommentInfo {
+	comments := make([]CommentInfo, 0)
+	for _, t := range stream.GetAllTokens() {
+		if t.GetChannel() != commentChannel || t.GetTokenType() == TokenEOF {
+			continue
+		}
+		info := CommentInfo{Text: t.GetText(), Line: t.GetLine(), Column: t.GetColumn()}
+		if next := stream.NextTokenOnChannel(t.GetTokenIndex()+1, stream.channel); next >= 0 {
+			if stream.Get(next).GetTokenType() != TokenEOF {
+				info.Node = commentsFollowingNode(tree, next)
+			}
+		}
+		comments = append(comments, info)
+	}
+	return comments
+}
+
+func commentsFollowingNode(t ParseTree, tokenIndex int) ParserRuleContext {
+	var innermost, spanning ParserRuleContext
+	for t != nil {
+		ctx, ok := t.(ParserRuleContext)
+		if !ok {
+			break
+		}
+		if ctx.GetStart() != nil && ctx.GetStart().GetTokenIndex() == tokenIndex {
+			innermost = ctx
+			if ctx.GetStop() != nil && ctx.GetStop().GetTokenIndex() > tokenIndex {
+				spanning = ctx
+			}
+		}
+
+		// descend into the child containing the token
+		var next ParseTree
+		for i := 0; i < ctx.GetChildCount(); i++ {
+			child := ctx.GetChild(i).(ParseTree)
+			interval := child.GetSourceInterval()
+			if interval.Start <= tokenIndex && tokenIndex <= interval.Stop {
+				next = child
+				break
+			}
+		}
+		t = next
+	}
+	if spanning != nil {
+		return spanning
+	}
+	return innermost
+}
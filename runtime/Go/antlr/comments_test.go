@@ -0,0 +1,38 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestExtractComments(t *testing.T) {
+	assert := assertNew(t)
+	input := "// adds one\ndef inc(x) {\n  // the result\n  x = x+1;\n  return x;\n}\n// trailing"
+	stream := NewCommonTokenStream(newExprCommentLexer(input), TokenDefaultChannel)
+	p := NewExprParser(stream)
+	tree := p.Prog()
+
+	comments := ExtractComments(stream, exprCommentLexerCommentChannel, tree)
+	assert.Equal(3, len(comments))
+
+	doc := comments[0]
+	assert.Equal("// adds one", doc.Text)
+	assert.Equal(1, doc.Line)
+	assert.Equal(0, doc.Column)
+	_, ok := doc.Node.(*Func_Context)
+	assert.Equal(true, ok)
+	assert.Equal("definc(x){x=x+1;returnx;}", doc.Node.GetText())
+
+	stat := comments[1]
+	assert.Equal("// the result", stat.Text)
+	assert.Equal(3, stat.Line)
+	assert.Equal(2, stat.Column)
+	_, ok = stat.Node.(*AssignContext)
+	assert.Equal(true, ok)
+
+	assert.Equal("// trailing", comments[2].Text)
+	assert.Nil(comments[2].Node)
+}
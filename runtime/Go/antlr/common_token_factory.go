@@ -4,6 +4,10 @@
 
 package antlr
 
+import (
+	"sync"
+)
+
 // TokenFactory creates CommonToken objects.
 type TokenFactory interface {
 	Create(source *TokenSourceCharStreamPair, ttype int, text string, channel, start, stop, line, column int) Token
@@ -54,3 +58,66 @@ func (c *CommonTokenFactory) createThin(ttype int, text string) Token {
 
 	return t
 }
+
+// PooledTokenFactory is a TokenFactory that recycles CommonToken values
+// through a sync.Pool to reduce allocations when lexing large inputs.
+//
+// Pooling is opt-in: set the factory on a lexer with SetTokenFactory, and
+// once a parse is complete and nothing refers to its tokens any more, hand
+// them back with Release. A released token is reused by a later Create, so
+// any token, parse tree node or error still holding it would silently see
+// another token's data. Without calls to Release the factory behaves like
+// CommonTokenFactory.
+type PooledTokenFactory struct {
+	copyText bool
+	pool     sync.Pool
+}
+
+// NewPooledTokenFactory creates a pooling factory; copyText has the same
+// meaning as for NewCommonTokenFactory.
+func NewPooledTokenFactory(copyText bool) *PooledTokenFactory {
+	f := &PooledTokenFactory{copyText: copyText}
+	f.pool.New = func() interface{} {
+		t := new(CommonToken)
+		t.BaseToken = new(BaseToken)
+		return t
+	}
+	return f
+}
+
+func (f *PooledTokenFactory) Create(source *TokenSourceCharStreamPair, ttype int, text string, channel, start, stop, line, column int) Token {
+	t := f.pool.Get().(*CommonToken)
+
+	// every field is set so nothing of a previous use survives
+	*t.BaseToken = BaseToken{
+		source:     source,
+		tokenType:  ttype,
+		channel:    channel,
+		start:      start,
+		stop:       stop,
+		tokenIndex: -1,
+		line:       line,
+		column:     column,
+	}
+
+	if text != "" {
+		t.SetText(text)
+	} else if f.copyText && source.charStream != nil {
+		t.SetText(source.charStream.GetTextFromInterval(NewInterval(start, stop)))
+	}
+
+	return t
+}
+
+// Release returns tokens to the pool for reuse. The caller must not use
+// them, or anything still referring to them, afterwards. Tokens that are not
+// CommonTokens are ignored.
+func (f *PooledTokenFactory) Release(tokens []Token) {
+	for _, t := range tokens {
+		if ct, ok := t.(*CommonToken); ok && ct.BaseToken != nil {
+			// drop references so the pool doesn't keep inputs alive
+			*ct.BaseToken = BaseToken{}
+			f.pool.Put(ct)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPooledTokenFactoryReset(t *testing.T) {
+	assert := assertNew(t)
+	input := NewInputStream("x = 42;")
+	lexer := NewExprLexer(input)
+	source := lexer.GetTokenSourceCharStreamPair()
+	factory := NewPooledTokenFactory(false)
+
+	used := factory.Create(source, ExprLexerINT, "override", TokenHiddenChannel, 4, 5, 3, 7)
+	used.SetTokenIndex(12)
+	factory.Release([]Token{used})
+
+	for i := 0; i < 3; i++ {
+		// whether or not the pool hands back the released token, the result
+		// must match a freshly created one
+		pooled := factory.Create(source, ExprLexerID, "", TokenDefaultChannel, 0, 0, 1, 0)
+		fresh := CommonTokenFactoryDEFAULT.Create(source, ExprLexerID, "", TokenDefaultChannel, 0, 0, 1, 0)
+
+		assert.Equal(fresh.GetTokenType(), pooled.GetTokenType())
+		assert.Equal("x", pooled.GetText())
+		assert.Equal(fresh.GetChannel(), pooled.GetChannel())
+		assert.Equal(fresh.GetStart(), pooled.GetStart())
+		assert.Equal(fresh.GetStop(), pooled.GetStop())
+		assert.Equal(fresh.GetLine(), pooled.GetLine())
+		assert.Equal(fresh.GetColumn(), pooled.GetColumn())
+		assert.Equal(-1, pooled.GetTokenIndex())
+		assert.Equal(fresh.(*CommonToken).String(), pooled.(*CommonToken).String())
+		factory.Release([]Token{pooled})
+	}
+}
+
+func TestPooledTokenFactoryLexer(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(x) { x = 1+2*3; }"
+
+	expected := NewExprLexer(NewInputStream(input)).GetAllTokens()
+
+	factory := NewPooledTokenFactory(false)
+	for i := 0; i < 3; i++ {
+		lexer := NewExprLexer(NewInputStream(input))
+		lexer.SetTokenFactory(factory)
+		tokens := lexer.GetAllTokens()
+		assert.Equal(tokensToString(expected), tokensToString(tokens))
+		factory.Release(tokens)
+	}
+}
+
+func TestPooledTokenFactoryCopyText(t *testing.T) {
+	assert := assertNew(t)
+	input := NewInputStream("x = 42;")
+	source := NewExprLexer(input).GetTokenSourceCharStreamPair()
+	factory := NewPooledTokenFactory(true)
+
+	token := factory.Create(source, ExprLexerINT, "", TokenDefaultChannel, 4, 5, 1, 4)
+	assert.Equal("42", token.(*CommonToken).text)
+}
+
+var commonTokenFactoryBenchmarkInput = strings.Repeat("def f(x, y) { x = 1+2*3; return (x-y)/4; }\n", 200)
+
+func BenchmarkCommonTokenFactory(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		lexer := NewExprLexer(NewInputStream(commonTokenFactoryBenchmarkInput))
+		lexer.GetAllTokens()
+	}
+}
+
+func BenchmarkPooledTokenFactory(b *testing.B) {
+	b.ReportAllocs()
+	factory := NewPooledTokenFactory(false)
+	for i := 0; i < b.N; i++ {
+		lexer := NewExprLexer(NewInputStream(commonTokenFactoryBenchmarkInput))
+		lexer.SetTokenFactory(factory)
+		factory.Release(lexer.GetAllTokens())
+	}
+}
@@ -5,6 +5,7 @@
 package antlr
 
 import (
+	"sort"
 	"strconv"
 )
 
@@ -42,6 +43,19 @@ type CommonTokenStream struct {
 	// tokens is all tokens fetched from the token source. The list is considered a
 	// complete view of the input once fetchedEOF is set to true.
 	tokens []Token
+
+	// checkpoints holds, for every checkpoint that has not been rewound or
+	// committed yet, the index it was taken at, innermost last.
+	checkpoints []tokenStreamCheckpoint
+
+	// channelFilter, if not nil, decides which tokens are on channel instead
+	// of their channel.
+	channelFilter func(tok Token) bool
+}
+
+type tokenStreamCheckpoint struct {
+	index  int
+	marker int
 }
 
 func NewCommonTokenStream(lexer Lexer, channel int) *CommonTokenStream {
@@ -53,16 +67,120 @@ func NewCommonTokenStream(lexer Lexer, channel int) *CommonTokenStream {
 	}
 }
 
+// GetAllTokens returns every token of the stream, on any channel, up to and
+// including EOF. It fills the stream from the token source first.
 func (c *CommonTokenStream) GetAllTokens() []Token {
+	c.Fill()
 	return c.tokens
 }
 
+// TokenTypeCounts returns how many tokens of each type the stream holds, on
+// any channel. It fills the stream from the token source first, so the count
+// for TokenEOF is 1.
+func (c *CommonTokenStream) TokenTypeCounts() map[int]int {
+	counts := make(map[int]int)
+	for _, t := range c.GetAllTokens() {
+		counts[t.GetTokenType()]++
+	}
+	return counts
+}
+
+// FindOverlappingTokens returns the pairs of indexes of tokens whose
+// character ranges start..stop overlap, which a lexer never produces and
+// usually points at a bug in a custom token factory or an Emit override. It
+// fills the stream from the token source first. Each pair holds the lower
+// index first and the pairs are sorted. EOF, empty tokens and tokens without
+// a position in the input are skipped.
+func (c *CommonTokenStream) FindOverlappingTokens() [][2]int {
+	spans := make([]Token, 0, len(c.GetAllTokens()))
+	for _, t := range c.tokens {
+		if t.GetTokenType() != TokenEOF && t.GetStart() >= 0 && t.GetStop() >= t.GetStart() {
+			spans = append(spans, t)
+		}
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].GetStart() < spans[j].GetStart()
+	})
+
+	overlaps := make([][2]int, 0)
+	active := make([]Token, 0)
+	for _, t := range spans {
+		// drop the tokens ending before t, keep the others as overlapping
+		n := 0
+		for _, a := range active {
+			if a.GetStop() >= t.GetStart() {
+				active[n] = a
+				n++
+				i, j := a.GetTokenIndex(), t.GetTokenIndex()
+				if i > j {
+					i, j = j, i
+				}
+				overlaps = append(overlaps, [2]int{i, j})
+			}
+		}
+		active = append(active[:n], t)
+	}
+	sort.Slice(overlaps, func(i, j int) bool {
+		if overlaps[i][0] != overlaps[j][0] {
+			return overlaps[i][0] < overlaps[j][0]
+		}
+		return overlaps[i][1] < overlaps[j][1]
+	})
+	return overlaps
+}
+
 func (c *CommonTokenStream) Mark() int {
 	return 0
 }
 
 func (c *CommonTokenStream) Release(marker int) {}
 
+// Checkpoint saves the current position of the stream so that it can be
+// restored with Rewind, for example after a failed speculative parse.
+// Checkpoints nest: the returned value identifies the checkpoint and Rewind
+// or Commit of a checkpoint also ends every checkpoint taken after it. A
+// checkpoint that is never ended shows up in OpenCheckpoints.
+func (c *CommonTokenStream) Checkpoint() int {
+	c.lazyInit()
+	c.checkpoints = append(c.checkpoints, tokenStreamCheckpoint{index: c.Index(), marker: c.Mark()})
+	return len(c.checkpoints) - 1
+}
+
+// Rewind restores the position saved by checkpoint, so that LT and LA
+// return the same tokens as when it was taken, and ends it along with the
+// checkpoints nested in it. It panics if checkpoint is not open.
+func (c *CommonTokenStream) Rewind(checkpoint int) {
+	c.endCheckpoint(checkpoint, "Rewind")
+	c.Seek(c.checkpoints[checkpoint].index)
+	c.checkpoints = c.checkpoints[:checkpoint]
+}
+
+// Commit ends checkpoint and the checkpoints nested in it without moving
+// the stream, keeping whatever was consumed since. It panics if checkpoint
+// is not open.
+func (c *CommonTokenStream) Commit(checkpoint int) {
+	c.endCheckpoint(checkpoint, "Commit")
+	c.checkpoints = c.checkpoints[:checkpoint]
+}
+
+// OpenCheckpoints returns the number of checkpoints that have been neither
+// rewound nor committed.
+func (c *CommonTokenStream) OpenCheckpoints() int {
+	return len(c.checkpoints)
+}
+
+// endCheckpoint releases the markers of checkpoint and of the checkpoints
+// nested in it, innermost first.
+func (c *CommonTokenStream) endCheckpoint(checkpoint int, caller string) {
+	if checkpoint < 0 || checkpoint >= len(c.checkpoints) {
+		panic(caller + " called with checkpoint " + strconv.Itoa(checkpoint) + ", but " +
+			strconv.Itoa(len(c.checkpoints)) + " checkpoints are open")
+	}
+	for i := len(c.checkpoints) - 1; i >= checkpoint; i-- {
+		c.Release(c.checkpoints[i].marker)
+	}
+}
+
 func (c *CommonTokenStream) reset() {
 	c.Seek(0)
 }
@@ -193,6 +311,34 @@ func (c *CommonTokenStream) SetTokenSource(tokenSource TokenSource) {
 	c.tokenSource = tokenSource
 	c.tokens = make([]Token, 0)
 	c.index = -1
+	c.checkpoints = nil
+}
+
+// SetChannelFilter makes filter decide which tokens the stream considers on
+// its channel, and so returns from LT and moves to on Consume, instead of
+// comparing their channel with the channel of the stream. filter may look at
+// anything about a token, its position as well as its type or channel, to
+// hide tokens in some regions of the input only. EOF is always on channel.
+// filter may be called several times for the same token, in any order, so it
+// should not depend on the order of calls. A nil filter restores channel
+// comparison.
+//
+// If the current token is no longer on channel the stream moves to the next
+// one that is.
+func (c *CommonTokenStream) SetChannelFilter(filter func(tok Token) bool) {
+	c.channelFilter = filter
+	if c.index >= 0 {
+		c.index = c.adjustSeekIndex(c.index)
+	}
+}
+
+// isOnChannel reports whether t is on channel, consulting the channel filter
+// for the channel of the stream.
+func (c *CommonTokenStream) isOnChannel(t Token, channel int) bool {
+	if c.channelFilter != nil && channel == c.channel {
+		return t.GetTokenType() == TokenEOF || c.channelFilter(t)
+	}
+	return t.GetChannel() == channel
 }
 
 // NextTokenOnChannel returns the index of the next token on channel given a
@@ -207,7 +353,7 @@ func (c *CommonTokenStream) NextTokenOnChannel(i, channel int) int {
 
 	token := c.tokens[i]
 
-	for token.GetChannel() != c.channel {
+	for !c.isOnChannel(token, c.channel) {
 		if token.GetTokenType() == TokenEOF {
 			return -1
 		}
@@ -224,7 +370,7 @@ func (c *CommonTokenStream) NextTokenOnChannel(i, channel int) int {
 // given a starting index. Returns i if tokens[i] is on channel. Returns -1 if
 // there are no tokens on channel between i and 0.
 func (c *CommonTokenStream) previousTokenOnChannel(i, channel int) int {
-	for i >= 0 && c.tokens[i].GetChannel() != channel {
+	for i >= 0 && !c.isOnChannel(c.tokens[i], channel) {
 		i--
 	}
 
@@ -434,7 +580,7 @@ func (c *CommonTokenStream) getNumberOfOnChannelTokens() int {
 	for i := 0; i < len(c.tokens); i++ {
 		t := c.tokens[i]
 
-		if t.GetChannel() == c.channel {
+		if c.isOnChannel(t, c.channel) {
 			n++
 		}
 
@@ -5,6 +5,7 @@
 package antlr
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -152,3 +153,129 @@ func TestCommonTokenStreamCannotConsumeEOF(t *testing.T) {
 	assert.Equal(1, tokens.Size())
 	assert.Panics(tokens.Consume)
 }
+
+func TestCommonTokenStreamGetAllTokens(t *testing.T) {
+	assert := assertNew(t)
+	tokens := NewCommonTokenStream(newExprCommentLexer("x = y; // done\nreturn x;"), TokenDefaultChannel)
+
+	all := tokens.GetAllTokens()
+	assert.Equal(9, len(all))
+	assert.Equal(exprCommentLexerCOMMENT, all[4].GetTokenType())
+	assert.Equal(TokenEOF, all[8].GetTokenType())
+	assert.Equal(all, tokens.GetAllTokens())
+}
+
+func TestCommonTokenStreamTokenTypeCounts(t *testing.T) {
+	assert := assertNew(t)
+	tokens := NewCommonTokenStream(newExprCommentLexer("x = y; // done\nreturn x;"), TokenDefaultChannel)
+
+	assert.Equal(map[int]int{
+		ExprLexerID:             3,
+		ExprLexerT__7:           1,
+		ExprLexerT__6:           2,
+		ExprLexerRETURN:         1,
+		exprCommentLexerCOMMENT: 1,
+		TokenEOF:                1,
+	}, tokens.TokenTypeCounts())
+}
+
+func TestCommonTokenStreamCheckpoint(t *testing.T) {
+	assert := assertNew(t)
+	tokens := NewCommonTokenStream(newExprCommentLexer("x = y; // done\nreturn x;"), TokenDefaultChannel)
+
+	tokens.Consume()
+	outer := tokens.Checkpoint()
+	assert.Equal(1, tokens.OpenCheckpoints())
+	tokens.Consume()
+	tokens.Consume()
+	inner := tokens.Checkpoint()
+	tokens.Consume()
+	assert.Equal(ExprLexerRETURN, tokens.LA(1))
+	assert.Equal(2, tokens.OpenCheckpoints())
+
+	// partial rewind to the inner checkpoint
+	tokens.Rewind(inner)
+	assert.Equal(1, tokens.OpenCheckpoints())
+	assert.Equal(3, tokens.Index())
+	assert.Equal(ExprLexerT__6, tokens.LA(1))
+	assert.Equal("y", tokens.LT(-1).GetText())
+	assert.Panics(func() { tokens.Rewind(inner) })
+
+	again := tokens.Checkpoint()
+	assert.Equal(inner, again)
+	tokens.Consume()
+	tokens.Consume()
+	assert.Equal("x", tokens.LT(1).GetText())
+
+	// rewinding the outer checkpoint ends the nested one too
+	tokens.Rewind(outer)
+	assert.Equal(0, tokens.OpenCheckpoints())
+	assert.Equal(1, tokens.Index())
+	assert.Equal("=", tokens.LT(1).GetText())
+	assert.Equal("x", tokens.LT(-1).GetText())
+	assert.Panics(func() { tokens.Commit(outer) })
+
+	// a committed checkpoint keeps the position
+	committed := tokens.Checkpoint()
+	tokens.Checkpoint()
+	tokens.Consume()
+	tokens.Commit(committed)
+	assert.Equal(0, tokens.OpenCheckpoints())
+	assert.Equal("y", tokens.LT(1).GetText())
+}
+
+// commonTokenStreamTestOverlapLexer emits an additional ID token spanning
+// every INT token and the character before it.
+type commonTokenStreamTestOverlapLexer struct {
+	*ExprLexer
+}
+
+func (l *commonTokenStreamTestOverlapLexer) Emit() Token {
+	t := l.BaseLexer.Emit()
+	if l.thetype == ExprLexerINT {
+		start := l.TokenStartCharIndex - 1
+		l.EmitToken(l.factory.Create(l.tokenFactorySourcePair, ExprLexerID, "", TokenDefaultChannel, start, t.GetStop(), l.TokenStartLine, l.TokenStartColumn-1))
+	}
+	return t
+}
+
+func TestCommonTokenStreamFindOverlappingTokens(t *testing.T) {
+	assert := assertNew(t)
+	tokens := NewCommonTokenStream(NewExprLexer(NewInputStream("x = 12; y = 3;")), TokenDefaultChannel)
+	assert.Equal([][2]int{}, tokens.FindOverlappingTokens())
+
+	l := &commonTokenStreamTestOverlapLexer{NewExprLexer(NewInputStream("x = 12; y = 3;"))}
+	l.Virt = l
+	tokens = NewCommonTokenStream(l, TokenDefaultChannel)
+	// the injected tokens 3 and 8 cover the INT tokens 2 and 7
+	assert.Equal([][2]int{{2, 3}, {7, 8}}, tokens.FindOverlappingTokens())
+}
+
+func TestCommonTokenStreamSetChannelFilter(t *testing.T) {
+	assert := assertNew(t)
+	input := "x; // keep\nreturn x; // drop\n"
+	tokens := NewCommonTokenStream(newExprCommentLexer(input), TokenDefaultChannel)
+	returnStart := strings.Index(input, "return")
+
+	// comments are on channel until the return statement
+	tokens.SetChannelFilter(func(tok Token) bool {
+		if tok.GetTokenType() == exprCommentLexerCOMMENT {
+			return tok.GetStart() < returnStart
+		}
+		return tok.GetChannel() == TokenDefaultChannel
+	})
+	types := make([]int, 0)
+	for tokens.LA(1) != TokenEOF {
+		types = append(types, tokens.LA(1))
+		tokens.Consume()
+	}
+	assert.Equal([]int{ExprLexerID, ExprLexerT__6, exprCommentLexerCOMMENT, ExprLexerRETURN, ExprLexerID, ExprLexerT__6}, types)
+	assert.Equal(ExprLexerT__6, tokens.LT(-1).GetTokenType())
+	assert.Equal(exprCommentLexerCOMMENT, tokens.LT(-4).GetTokenType())
+
+	// removing the filter hides the comment under the current position
+	tokens.Seek(2)
+	assert.Equal(exprCommentLexerCOMMENT, tokens.LA(1))
+	tokens.SetChannelFilter(nil)
+	assert.Equal(ExprLexerRETURN, tokens.LA(1))
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// DepthAwareListener receives the events of ParseTreeWalker.WalkWithDepth,
+// each with the depth of its node: 0 for the root of the walk, one more
+// than its parent for any other node. Enter and exit events of a rule node
+// carry the same depth, so indentation can be derived from it directly.
+type DepthAwareListener interface {
+	EnterRuleAtDepth(ctx ParserRuleContext, depth int)
+	ExitRuleAtDepth(ctx ParserRuleContext, depth int)
+	VisitTerminalAtDepth(node TerminalNode, depth int)
+	VisitErrorNodeAtDepth(node ErrorNode, depth int)
+}
+
+// BaseDepthAwareListener implements every DepthAwareListener method as a
+// no-op, for embedding in listeners interested in some events only.
+type BaseDepthAwareListener struct{}
+
+var _ DepthAwareListener = &BaseDepthAwareListener{}
+
+func (l *BaseDepthAwareListener) EnterRuleAtDepth(ctx ParserRuleContext, depth int) {}
+func (l *BaseDepthAwareListener) ExitRuleAtDepth(ctx ParserRuleContext, depth int)  {}
+func (l *BaseDepthAwareListener) VisitTerminalAtDepth(node TerminalNode, depth int) {}
+func (l *BaseDepthAwareListener) VisitErrorNodeAtDepth(node ErrorNode, depth int)   {}
+
+// WalkWithDepth walks t depth-first like Walk, calling EnterRuleAtDepth
+// before and ExitRuleAtDepth after the children of every rule node.
+func (p *ParseTreeWalker) WalkWithDepth(listener DepthAwareListener, t Tree) {
+	p.walkWithDepth(listener, t, 0)
+}
+
+func (p *ParseTreeWalker) walkWithDepth(listener DepthAwareListener, t Tree, depth int) {
+	switch tt := t.(type) {
+	case ErrorNode:
+		listener.VisitErrorNodeAtDepth(tt, depth)
+	case TerminalNode:
+		listener.VisitTerminalAtDepth(tt, depth)
+	default:
+		ctx := t.(RuleNode).GetRuleContext().(ParserRuleContext)
+		listener.EnterRuleAtDepth(ctx, depth)
+		for i := 0; i < t.GetChildCount(); i++ {
+			p.walkWithDepth(listener, t.GetChild(i), depth+1)
+		}
+		listener.ExitRuleAtDepth(ctx, depth)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// depthAwareTestListener renders the walk as one line per event, indented by
+// the depth of its node.
+type depthAwareTestListener struct {
+	*BaseDepthAwareListener
+
+	lines []string
+}
+
+func (l *depthAwareTestListener) add(depth int, s string) {
+	l.lines = append(l.lines, strings.Repeat("  ", depth)+s+" "+strconv.Itoa(depth))
+}
+
+func (l *depthAwareTestListener) EnterRuleAtDepth(ctx ParserRuleContext, depth int) {
+	l.add(depth, exprParser_ruleNames[ctx.GetRuleIndex()])
+}
+
+func (l *depthAwareTestListener) ExitRuleAtDepth(ctx ParserRuleContext, depth int) {
+	l.add(depth, "/"+exprParser_ruleNames[ctx.GetRuleIndex()])
+}
+
+func (l *depthAwareTestListener) VisitTerminalAtDepth(node TerminalNode, depth int) {
+	l.add(depth, node.GetText())
+}
+
+func TestParseTreeWalkerWalkWithDepth(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x;")
+	tree := p.Stat()
+
+	l := &depthAwareTestListener{BaseDepthAwareListener: &BaseDepthAwareListener{}}
+	ParseTreeWalkerDefault.WalkWithDepth(l, tree)
+	assert.Equal([]string{
+		"stat 0",
+		"  expr 1",
+		"    primary 2",
+		"      x 3",
+		"    /primary 2",
+		"  /expr 1",
+		"  ; 1",
+		"/stat 0",
+	}, l.lines)
+}
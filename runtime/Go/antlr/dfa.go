@@ -171,6 +171,16 @@ func (d *DFA) String(literalNames []string, symbolicNames []string) string {
 	return NewDFASerializer(d, literalNames, symbolicNames).String()
 }
 
+// ToString renders the edges of d one per line, in the format of the
+// reference runtimes: "s0-ID->s1" for an edge on token ID, with accept states
+// written as ":s2=>1" where 1 is the predicted alternative and states that
+// require full context marked with "^". Edge labels use the literal name of
+// the token if it has one, its symbolic name otherwise. A decision that has
+// not been used for prediction yet renders as the empty string.
+func (d *DFA) ToString(literalNames []string, symbolicNames []string) string {
+	return d.String(literalNames, symbolicNames)
+}
+
 func (d *DFA) ToLexerString() string {
 	if d.getS0() == nil {
 		return ""
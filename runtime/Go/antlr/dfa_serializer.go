@@ -71,9 +71,9 @@ func (d *DFASerializer) String() string {
 func (d *DFASerializer) getEdgeLabel(i int) string {
 	if i == 0 {
 		return "EOF"
-	} else if d.literalNames != nil && i-1 < len(d.literalNames) {
+	} else if d.literalNames != nil && i-1 < len(d.literalNames) && d.literalNames[i-1] != "" {
 		return d.literalNames[i-1]
-	} else if d.symbolicNames != nil && i-1 < len(d.symbolicNames) {
+	} else if d.symbolicNames != nil && i-1 < len(d.symbolicNames) && d.symbolicNames[i-1] != "" {
 		return d.symbolicNames[i-1]
 	}
 
@@ -0,0 +1,35 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestDFAToString(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x = 1; y; return x; }")
+	p.Prog()
+	dfas := p.GetATNSimulator().DecisionToDFA()
+
+	// decision 3 chooses between the alternatives of 'stat'
+	assert.Equal(
+		"s0-'return'->:s4=>3\n"+
+			"s0-ID->s1\n"+
+			"s1-';'->:s3=>1\n"+
+			"s1-'='->:s2=>2\n",
+		dfas[3].ToString(p.LiteralNames, p.SymbolicNames))
+
+	// decision 0 is the LL(1) loop of 'prog' and never reaches the DFA
+	assert.Equal("", dfas[0].ToString(p.LiteralNames, p.SymbolicNames))
+}
+
+func TestDFAToStringWithoutNames(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { y; }")
+	p.Prog()
+
+	assert.Equal("s0-14->s1\ns1-7->:s2=>1\n", p.GetATNSimulator().DecisionToDFA()[3].ToString(nil, nil))
+}
@@ -106,3 +106,77 @@ func (p *ProxyErrorListener) ReportContextSensitivity(recognizer Parser, dfa *DF
 		d.ReportContextSensitivity(recognizer, dfa, startIndex, stopIndex, prediction, configs)
 	}
 }
+
+// SyntaxError describes one syntax error collected by a
+// CollectingErrorListener.
+type SyntaxError struct {
+	Line   int
+	Column int
+
+	// OffendingText is the text of the offending token, or "" if the
+	// recognizer did not report one, as for lexer errors.
+	OffendingText string
+	Msg           string
+
+	// Exception is the exception that caused the error, or nil if the error
+	// was reported without one, as for single token insertion or deletion.
+	Exception RecognitionException
+}
+
+// CollectingErrorListener records every syntax error reported to it so that
+// they can be inspected after parsing. It is added next to the other
+// listeners with AddErrorListener and can be reused for another parse after
+// Clear.
+type CollectingErrorListener struct {
+	*DefaultErrorListener
+
+	errors []SyntaxError
+}
+
+func NewCollectingErrorListener() *CollectingErrorListener {
+	return &CollectingErrorListener{DefaultErrorListener: NewDefaultErrorListener()}
+}
+
+func (c *CollectingErrorListener) SyntaxError(recognizer Recognizer, offendingSymbol interface{}, line, column int, msg string, e RecognitionException) {
+	err := SyntaxError{Line: line, Column: column, Msg: msg, Exception: e}
+	if t, ok := offendingSymbol.(Token); ok && t != nil {
+		err.OffendingText = t.GetText()
+	}
+	c.errors = append(c.errors, err)
+}
+
+// Errors returns the errors collected since the listener was created or
+// last cleared, in the order they were reported.
+func (c *CollectingErrorListener) Errors() []SyntaxError {
+	return c.errors
+}
+
+// Clear discards the collected errors.
+func (c *CollectingErrorListener) Clear() {
+	c.errors = nil
+}
+
+// AmbiguousText returns the source text of the tokens start..stop of ts,
+// both included, as passed to ReportAmbiguity. Hidden characters between
+// the tokens, such as whitespace, are included when the tokens still refer
+// to their CharStream. A trailing EOF token contributes no text.
+func AmbiguousText(ts TokenStream, start, stop int) string {
+	if start < 0 || stop < start || start >= ts.Size() {
+		return ""
+	}
+	if stop >= ts.Size() {
+		stop = ts.Size() - 1
+	}
+	for stop >= start && ts.Get(stop).GetTokenType() == TokenEOF {
+		stop--
+	}
+	if stop < start {
+		return ""
+	}
+
+	first, last := ts.Get(start), ts.Get(stop)
+	if input := first.GetInputStream(); input != nil && first.GetStart() >= 0 && last.GetStop() >= first.GetStart() {
+		return input.GetTextFromInterval(NewInterval(first.GetStart(), last.GetStop()))
+	}
+	return ts.GetTextFromInterval(NewInterval(start, stop))
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+type errorListenerTestAmbiguityListener struct {
+	*DefaultErrorListener
+	texts  []string
+	starts []int
+	stops  []int
+}
+
+func (l *errorListenerTestAmbiguityListener) ReportAmbiguity(recognizer Parser, dfa *DFA, startIndex, stopIndex int, exact bool, ambigAlts *BitSet, configs ATNConfigSet) {
+	l.texts = append(l.texts, AmbiguousText(recognizer.GetTokenStream(), startIndex, stopIndex))
+	l.starts = append(l.starts, startIndex)
+	l.stops = append(l.stops, stopIndex)
+}
+
+func TestAmbiguousText(t *testing.T) {
+	assert := assertNew(t)
+	p := newAmbParser(NewCommonTokenStream(NewExprLexer(NewInputStream("a  b")), TokenDefaultChannel))
+	listener := &errorListenerTestAmbiguityListener{DefaultErrorListener: NewDefaultErrorListener()}
+	p.RemoveErrorListeners()
+	p.AddErrorListener(listener)
+
+	p.S()
+	assert.Equal(0, p.GetNumberOfSyntaxErrors())
+	assert.Equal([]int{0}, listener.starts)
+	assert.Equal([]int{1}, listener.stops)
+	assert.Equal([]string{"a  b"}, listener.texts)
+}
+
+func TestAmbiguousTextBounds(t *testing.T) {
+	assert := assertNew(t)
+	ts := NewCommonTokenStream(NewExprLexer(NewInputStream("x =  y;")), TokenDefaultChannel)
+	ts.Fill()
+
+	assert.Equal("x =  y", AmbiguousText(ts, 0, 2))
+	assert.Equal("=", AmbiguousText(ts, 1, 1))
+	assert.Equal("y;", AmbiguousText(ts, 2, 10))
+	assert.Equal("", AmbiguousText(ts, 4, 4))
+	assert.Equal("", AmbiguousText(ts, 2, 1))
+	assert.Equal("", AmbiguousText(ts, -1, 1))
+}
+
+func TestCollectingErrorListener(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) {\n  x = ;\n  y = 1 2;\n}")
+	collector := NewCollectingErrorListener()
+	p.RemoveErrorListeners()
+	p.AddErrorListener(NewDefaultErrorListener())
+	p.AddErrorListener(collector)
+	p.Prog()
+
+	errors := collector.Errors()
+	assert.Equal(3, len(errors))
+	assert.Equal(p.GetNumberOfSyntaxErrors(), len(errors))
+
+	assert.Equal(2, errors[0].Line)
+	assert.Equal(6, errors[0].Column)
+	assert.Equal(";", errors[0].OffendingText)
+	assert.Equal("extraneous input ';' expecting {'(', ID, INT}", errors[0].Msg)
+	assert.Nil(errors[0].Exception)
+
+	assert.Equal(3, errors[1].Line)
+	assert.Equal(4, errors[1].Column)
+	assert.Equal("=", errors[1].OffendingText)
+	assert.Equal("mismatched input '=' expecting ';'", errors[1].Msg)
+	_, ok := errors[1].Exception.(*InputMisMatchException)
+	assert.Equal(true, ok)
+
+	assert.Equal(3, errors[2].Line)
+	assert.Equal(8, errors[2].Column)
+	assert.Equal("2", errors[2].OffendingText)
+
+	collector.Clear()
+	assert.Equal(0, len(collector.Errors()))
+	p.SetInputStream(newExprTokenStream("def f(x) { x = 1; }"))
+	p.Prog()
+	assert.Equal(0, len(collector.Errors()))
+}
+
+func TestCollectingErrorListenerLexer(t *testing.T) {
+	assert := assertNew(t)
+	l := NewExprLexer(NewInputStream("x\n  # y"))
+	collector := NewCollectingErrorListener()
+	l.RemoveErrorListeners()
+	l.AddErrorListener(collector)
+	l.GetAllTokens()
+
+	errors := collector.Errors()
+	assert.Equal(1, len(errors))
+	assert.Equal(2, errors[0].Line)
+	assert.Equal(2, errors[0].Column)
+	assert.Equal("", errors[0].OffendingText)
+	assert.Equal("token recognition error at: '#'", errors[0].Msg)
+}
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type ErrorStrategy interface {
@@ -28,6 +29,9 @@ type DefaultErrorStrategy struct {
 	errorRecoveryMode bool
 	lastErrorIndex    int
 	lastErrorStates   *IntervalSet
+
+	recoveryDeadline time.Duration
+	recoveryTime     time.Duration
 }
 
 var _ ErrorStrategy = &DefaultErrorStrategy{}
@@ -57,8 +61,46 @@ func NewDefaultErrorStrategy() *DefaultErrorStrategy {
 
 // <p>The default implementation simply calls {@link //endErrorCondition} to
 // ensure that the handler is not in error recovery mode.</p>
+//
+// The time spent recovering, see SetRecoveryDeadline, is reset as well.
 func (d *DefaultErrorStrategy) reset(recognizer Parser) {
 	d.endErrorCondition(recognizer)
+	d.recoveryTime = 0
+}
+
+// SetRecoveryDeadline bounds the total time Recover, RecoverInline and Sync
+// may spend recovering from syntax errors during a parse. Once recovery took
+// longer than deadline altogether, the strategy panics with a
+// *RecoveryDeadlineError instead of recovering further, which ends the parse
+// like the ParseCancellationException of a BailErrorStrategy. The time is
+// counted from the start of each parse, when the parser gets its input, and
+// a deadline of 0 or less, the default, does not bound it.
+//
+// Recovery is timed in steps, so the deadline is only checked once the step
+// that exceeds it completes.
+func (d *DefaultErrorStrategy) SetRecoveryDeadline(deadline time.Duration) {
+	d.recoveryDeadline = deadline
+}
+
+// startRecovery returns the time a recovery step starts, or the zero time if
+// recovery is not bounded, for endRecovery.
+func (d *DefaultErrorStrategy) startRecovery() time.Time {
+	if d.recoveryDeadline <= 0 {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// endRecovery adds the time spent since start to the recovery time and
+// panics if that exceeds the deadline.
+func (d *DefaultErrorStrategy) endRecovery(recognizer Parser, start time.Time) {
+	if start.IsZero() {
+		return
+	}
+	d.recoveryTime += time.Since(start)
+	if d.recoveryTime > d.recoveryDeadline {
+		panic(NewRecoveryDeadlineError(recognizer, d.recoveryDeadline, d.recoveryTime))
+	}
 }
 
 //
@@ -144,6 +186,7 @@ func (d *DefaultErrorStrategy) ReportError(recognizer Parser, e RecognitionExcep
 // that can follow the current rule.</p>
 //
 func (d *DefaultErrorStrategy) Recover(recognizer Parser, e RecognitionException) {
+	defer d.endRecovery(recognizer, d.startRecovery())
 
 	if d.lastErrorIndex == recognizer.GetInputStream().Index() &&
 		d.lastErrorStates != nil && d.lastErrorStates.contains(recognizer.GetState()) {
@@ -222,6 +265,7 @@ func (d *DefaultErrorStrategy) Sync(recognizer Parser) {
 		return
 	}
 
+	defer d.endRecovery(recognizer, d.startRecovery())
 	switch s.GetStateType() {
 	case ATNStateBlockStart, ATNStateStarBlockStart, ATNStatePlusBlockStart, ATNStateStarLoopEntry:
 		// Report error and recover if possible
@@ -402,6 +446,7 @@ func (d *DefaultErrorStrategy) ReportMissingToken(recognizer Parser) {
 // in rule {@code atom}. It can assume that you forgot the {@code ')'}.
 //
 func (d *DefaultErrorStrategy) RecoverInline(recognizer Parser) Token {
+	defer d.endRecovery(recognizer, d.startRecovery())
 	// SINGLE TOKEN DELETION
 	MatchedSymbol := d.SingleTokenDeletion(recognizer)
 	if MatchedSymbol != nil {
@@ -0,0 +1,44 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultErrorStrategyRecoveryDeadline(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(x) {" + strings.Repeat(" x = ; ) y ) = ;", 50) + " }"
+
+	// without a deadline every error is recovered from
+	p := newExprParserFor(input)
+	p.RemoveErrorListeners()
+	p.Prog()
+	assert.Equal(true, p.GetNumberOfSyntaxErrors() > 50)
+
+	p = newExprParserFor(input)
+	p.RemoveErrorListeners()
+	strategy := NewDefaultErrorStrategy()
+	strategy.SetRecoveryDeadline(time.Nanosecond)
+	p.SetErrorHandler(strategy)
+
+	var err interface{}
+	func() {
+		defer func() { err = recover() }()
+		p.Prog()
+	}()
+	deadlineErr, ok := err.(*RecoveryDeadlineError)
+	assert.Equal(true, ok)
+	assert.Equal(time.Nanosecond, deadlineErr.Deadline)
+	assert.Equal(true, deadlineErr.Spent > deadlineErr.Deadline)
+
+	// the time spent is reset for the next parse
+	strategy.SetRecoveryDeadline(time.Hour)
+	p.SetInputStream(NewCommonTokenStream(NewExprLexer(NewInputStream(input)), TokenDefaultChannel))
+	p.Prog()
+	assert.Equal(true, p.GetNumberOfSyntaxErrors() > 50)
+}
@@ -4,6 +4,11 @@
 
 package antlr
 
+import (
+	"fmt"
+	"time"
+)
+
 // The root of the ANTLR exception hierarchy. In general, ANTLR tracks just
 //  3 kinds of errors: prediction errors, failed predicate errors, and
 //  mismatched input errors. In each case, the parser knows where it is
@@ -171,6 +176,23 @@ func NewNoViableAltException(recognizer Parser, input TokenStream, startToken To
 	return n
 }
 
+// GetDecision returns the number of the decision for which no viable
+// alternative was found, or -1 if it is not known. It is derived from the
+// ATN state the parser was in, which is the decision state.
+func (n *NoViableAltException) GetDecision() int {
+	if n.recognizer == nil || n.offendingState < 0 {
+		return -1
+	}
+	atn := n.recognizer.GetATN()
+	if atn == nil || n.offendingState >= len(atn.states) {
+		return -1
+	}
+	if s, ok := atn.states[n.offendingState].(DecisionState); ok {
+		return s.getDecision()
+	}
+	return -1
+}
+
 type InputMisMatchException struct {
 	*BaseRecognitionException
 }
@@ -239,3 +261,30 @@ func NewParseCancellationException() *ParseCancellationException {
 	//	Error.captureStackTrace(this, ParseCancellationException)
 	return new(ParseCancellationException)
 }
+
+// RecoveryDeadlineError is panicked by a DefaultErrorStrategy whose recovery
+// from syntax errors took longer than the deadline set with
+// SetRecoveryDeadline. It is not a RecognitionException, so the parser does
+// not try to recover from it and the panic leaves the start rule.
+type RecoveryDeadlineError struct {
+	// Deadline is the deadline that was exceeded, Spent the time spent
+	// recovering when that was noticed.
+	Deadline time.Duration
+	Spent    time.Duration
+
+	// OffendingToken is the current token of the parser when the deadline
+	// was exceeded.
+	OffendingToken Token
+}
+
+func NewRecoveryDeadlineError(recognizer Parser, deadline, spent time.Duration) *RecoveryDeadlineError {
+	return &RecoveryDeadlineError{
+		Deadline:       deadline,
+		Spent:          spent,
+		OffendingToken: recognizer.GetCurrentToken(),
+	}
+}
+
+func (e *RecoveryDeadlineError) Error() string {
+	return fmt.Sprintf("error recovery took %v, longer than the deadline of %v, at token %v", e.Spent, e.Deadline, e.OffendingToken)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestNoViableAltExceptionGetDecision(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x ); }")
+	collector := NewCollectingErrorListener()
+	p.RemoveErrorListeners()
+	p.AddErrorListener(collector)
+	p.Prog()
+
+	assert.Equal(1, len(collector.Errors()))
+	e, ok := collector.Errors()[0].Exception.(*NoViableAltException)
+	assert.Equal(true, ok)
+	assert.Equal(3, e.GetDecision())
+}
+
+func TestNoViableAltExceptionGetDecisionUnknown(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x; }")
+	e := NewNoViableAltException(p, nil, nil, nil, nil, nil)
+
+	// the parser has not entered any ATN state yet
+	assert.Equal(-1, e.GetDecision())
+
+	e.recognizer = nil
+	assert.Equal(-1, e.GetDecision())
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// FilteredTokenStream is a CommonTokenStream, parsing the tokens on its
+// channel, that also gives access to the tokens of any other channel, for
+// example to read comments from a hidden channel while a parser reads the
+// default one.
+type FilteredTokenStream struct {
+	*CommonTokenStream
+}
+
+// NewFilteredTokenStream creates a stream of the tokens of source whose
+// parser sees the tokens on channel.
+func NewFilteredTokenStream(source TokenSource, channel int) *FilteredTokenStream {
+	s := NewCommonTokenStream(nil, channel)
+	s.SetTokenSource(source)
+	return &FilteredTokenStream{s}
+}
+
+// GetChannelTokens returns, in input order, the tokens of the stream on
+// channel, EOF excluded. It reads the token source up to EOF but does not
+// move the stream, so it may be called before, while or after a parser reads
+// the stream without changing what the parser sees.
+func (f *FilteredTokenStream) GetChannelTokens(channel int) []Token {
+	tokens := make([]Token, 0)
+	for _, t := range f.GetAllTokens() {
+		if t.GetChannel() == channel && t.GetTokenType() != TokenEOF {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestFilteredTokenStream(t *testing.T) {
+	assert := assertNew(t)
+	input := "// adds one\ndef inc(x) { // body\n  x = x+1; // bump\n  return x;\n}\n// trailing"
+	stream := NewFilteredTokenStream(newExprCommentLexer(input), TokenDefaultChannel)
+
+	// reading comments mid-parse leaves the parser where it was
+	stream.Consume()
+	stream.Consume()
+	comments := stream.GetChannelTokens(exprCommentLexerCommentChannel)
+	assert.Equal("(", stream.LT(1).GetText())
+	stream.Seek(0)
+
+	texts := make([]string, len(comments))
+	for i, c := range comments {
+		texts[i] = c.GetText()
+	}
+	assert.Equal([]string{"// adds one", "// body", "// bump", "// trailing"}, texts)
+	assert.Equal(3, comments[2].GetLine())
+	for i := 1; i < len(comments); i++ {
+		assert.Equal(true, comments[i-1].GetTokenIndex() < comments[i].GetTokenIndex())
+	}
+
+	tree := NewExprParser(stream).Prog()
+	expected := NewExprParser(NewCommonTokenStream(newExprCommentLexer(input), TokenDefaultChannel)).Prog()
+	assert.Equal(expected.ToStringTree(exprParser_ruleNames, nil), tree.ToStringTree(exprParser_ruleNames, nil))
+
+	assert.Equal(comments, stream.GetChannelTokens(exprCommentLexerCommentChannel))
+	assert.Equal(0, len(stream.GetChannelTokens(TokenHiddenChannel)))
+	assert.Equal(16, len(stream.GetChannelTokens(TokenDefaultChannel)))
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// ForestRuleIndex is the rule index of the synthetic root created by
+// NewForestNode. It matches no rule of any grammar, so listeners can tell
+// the forest root apart from the trees it holds.
+const ForestRuleIndex = -2
+
+type forestNode struct {
+	*BaseParserRuleContext
+}
+
+// NewForestNode returns a synthetic root whose children are trees, for
+// example the parse trees of several files, so that a single walk or visit
+// processes all of them. The root has rule index ForestRuleIndex and a source
+// interval spanning those of all trees. The trees become children of the
+// root, so their parent is set to it.
+func NewForestNode(trees []ParseTree) RuleNode {
+	f := &forestNode{NewBaseParserRuleContext(nil, -1)}
+	f.RuleIndex = ForestRuleIndex
+	for _, t := range trees {
+		f.children = append(f.children, t)
+		t.SetParent(f)
+	}
+	return f
+}
+
+func (f *forestNode) GetRuleContext() RuleContext {
+	return f
+}
+
+func (f *forestNode) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(f, ruleNames, recog)
+}
+
+func (f *forestNode) GetSourceInterval() *Interval {
+	interval := TreeInvalidInterval
+	for _, child := range f.GetChildren() {
+		i := child.(ParseTree).GetSourceInterval()
+		if i.Start < 0 || i.SpanLength() == 0 {
+			continue
+		}
+		interval = interval.SpanUnion(i)
+	}
+	return interval
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"strings"
+	"testing"
+)
+
+type forestNodeTestListener struct {
+	*BaseParseTreeListener
+
+	rules     []int
+	terminals []string
+}
+
+func (l *forestNodeTestListener) EnterEveryRule(ctx ParserRuleContext) {
+	l.rules = append(l.rules, ctx.GetRuleIndex())
+}
+
+func (l *forestNodeTestListener) VisitTerminal(node TerminalNode) {
+	l.terminals = append(l.terminals, node.GetText())
+}
+
+func TestForestNode(t *testing.T) {
+	assert := assertNew(t)
+	a := newExprParserFor("def f(x) { x; }").Prog()
+	b := newExprParserFor("def g(y) { return y; }\ndef h(z) { z; }").Prog()
+
+	forest := NewForestNode([]ParseTree{a, b})
+	assert.Equal(ForestRuleIndex, forest.GetRuleContext().GetRuleIndex())
+	assert.Equal(2, forest.GetChildCount())
+	assert.Equal(forest, a.GetParent())
+	assert.Equal(forest, b.GetParent())
+	assert.Equal(0, forest.GetSourceInterval().Start)
+	assert.Equal(b.GetSourceInterval().Stop, forest.GetSourceInterval().Stop)
+	assert.Equal("(<forest> "+a.ToStringTree(exprParser_ruleNames, nil)+" "+b.ToStringTree(exprParser_ruleNames, nil)+")",
+		forest.ToStringTree(exprParser_ruleNames, nil))
+
+	l := &forestNodeTestListener{BaseParseTreeListener: &BaseParseTreeListener{}}
+	ParseTreeWalkerDefault.Walk(l, forest)
+	assert.Equal(ForestRuleIndex, l.rules[0])
+	progs := 0
+	for _, r := range l.rules {
+		if r == ExprParserRULE_prog {
+			progs++
+		}
+	}
+	assert.Equal(2, progs)
+	assert.Equal(a.GetText()+b.GetText(), forest.GetText())
+	assert.Equal(len(TreesFindAllTokenNodes(a, ExprLexerID))+len(TreesFindAllTokenNodes(b, ExprLexerID)),
+		len(TreesFindAllTokenNodes(forest, ExprLexerID)))
+	assert.Equal("deff(x){x;}defg(y){returny;}defh(z){z;}", strings.Join(l.terminals, ""))
+}
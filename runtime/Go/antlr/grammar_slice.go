@@ -0,0 +1,115 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"sort"
+)
+
+// GrammarSliceRecorder records the part of a grammar a parser exercises:
+// the rules it enters and the alternatives it chooses at each decision.
+// Parsing a corpus with one recorder gives the slice of the grammar the
+// corpus needs.
+//
+// Alternatives are recorded as predicted by AdaptivePredict, and, for the
+// outer block of rules that are not left recursive, as entered by
+// EnterOuterAlt, which covers blocks the generated code decides with LL(1)
+// switches.
+type GrammarSliceRecorder struct {
+	*BaseParseTreeListener
+
+	atn   *ATN
+	rules map[int]bool
+	alts  map[int]map[int]bool
+
+	// next is the prediction observer the recorder replaced, if any.
+	next func(decision, alt int)
+}
+
+// NewGrammarSliceRecorder creates a recorder for parser, adding it as a
+// parse listener and as the prediction observer of the parser's
+// interpreter. An observer already set on the interpreter, such as another
+// recorder, is still called after the recorder.
+func NewGrammarSliceRecorder(parser *BaseParser) *GrammarSliceRecorder {
+	r := &GrammarSliceRecorder{
+		BaseParseTreeListener: &BaseParseTreeListener{},
+		atn:                   parser.GetATN(),
+		rules:                 make(map[int]bool),
+		alts:                  make(map[int]map[int]bool),
+	}
+	interp := parser.GetInterpreter()
+	r.next = interp.predictionObserver
+	parser.AddParseListener(r)
+	interp.SetPredictionObserver(r.predicted)
+	return r
+}
+
+// UsedGrammarSlice returns the indexes of the rules entered and, for each
+// decision reached, the alternatives chosen, all in ascending order.
+func (r *GrammarSliceRecorder) UsedGrammarSlice() (rules []int, altsByDecision map[int][]int) {
+	rules = make([]int, 0, len(r.rules))
+	for rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Ints(rules)
+	altsByDecision = make(map[int][]int, len(r.alts))
+	for decision, set := range r.alts {
+		alts := make([]int, 0, len(set))
+		for alt := range set {
+			alts = append(alts, alt)
+		}
+		sort.Ints(alts)
+		altsByDecision[decision] = alts
+	}
+	return rules, altsByDecision
+}
+
+func (r *GrammarSliceRecorder) EnterEveryRule(ctx ParserRuleContext) {
+	r.rules[ctx.GetRuleIndex()] = true
+}
+
+func (r *GrammarSliceRecorder) ExitEveryRule(ctx ParserRuleContext) {
+	alt := ctx.GetOuterAltNumber()
+	if alt == ATNInvalidAltNumber {
+		return
+	}
+	if decision := r.outerDecision(ctx.GetRuleIndex()); decision >= 0 {
+		r.recordAlt(decision, alt)
+	}
+}
+
+func (r *GrammarSliceRecorder) predicted(decision, alt int) {
+	r.recordAlt(decision, alt)
+	if r.next != nil {
+		r.next(decision, alt)
+	}
+}
+
+func (r *GrammarSliceRecorder) recordAlt(decision, alt int) {
+	if alt == ATNInvalidAltNumber {
+		return
+	}
+	set := r.alts[decision]
+	if set == nil {
+		set = make(map[int]bool)
+		r.alts[decision] = set
+	}
+	set[alt] = true
+}
+
+// outerDecision returns the decision choosing the outer alternative of a
+// rule, or -1 if the rule has a single alternative or is left recursive, in
+// which case the outer alternatives of its contexts are not those of the
+// decision.
+func (r *GrammarSliceRecorder) outerDecision(ruleIndex int) int {
+	start := r.atn.ruleToStartState[ruleIndex]
+	if start.isPrecedenceRule {
+		return -1
+	}
+	if d, ok := start.GetTransitions()[0].getTarget().(DecisionState); ok {
+		return d.getDecision()
+	}
+	return -1
+}
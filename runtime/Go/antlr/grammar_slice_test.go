@@ -0,0 +1,63 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestGrammarSliceRecorder(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x = y;")
+	r := NewGrammarSliceRecorder(p.BaseParser)
+	p.Stat()
+
+	rules, alts := r.UsedGrammarSlice()
+	assert.Equal([]int{ExprParserRULE_stat, ExprParserRULE_expr, ExprParserRULE_primary}, rules)
+	// stat chose assign (decision 3), the expr loop was left at once
+	// (decision 5) and primary chose ID (decision 6)
+	assert.Equal(map[int][]int{3: {2}, 5: {2}, 6: {2}}, alts)
+
+	// the slice grows over a corpus
+	p.SetInputStream(NewCommonTokenStream(NewExprLexer(NewInputStream("return 1 * 2;")), TokenDefaultChannel))
+	p.Stat()
+	rules, alts = r.UsedGrammarSlice()
+	assert.Equal([]int{ExprParserRULE_stat, ExprParserRULE_expr, ExprParserRULE_primary}, rules)
+	assert.Equal(map[int][]int{3: {2, 3}, 4: {1}, 5: {1, 2}, 6: {1, 2}}, alts)
+}
+
+func TestGrammarSliceRecorderProfiling(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { return x; }")
+	p.SetProfile(true)
+	r := NewGrammarSliceRecorder(p.BaseParser)
+	p.Prog()
+
+	rules, alts := r.UsedGrammarSlice()
+	assert.Equal([]int{0, 1, 2, 3, 4, 5, 6}, rules)
+	assert.Equal(map[int][]int{3: {3}, 5: {2}, 6: {2}}, alts)
+}
+
+func TestGrammarSliceRecorderChainsObservers(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x = y;")
+	var predictions []int
+	p.GetInterpreter().SetPredictionObserver(func(decision, alt int) {
+		predictions = append(predictions, decision)
+	})
+
+	first := NewGrammarSliceRecorder(p.BaseParser)
+	rules, alts := first.UsedGrammarSlice()
+	assert.Equal([]int{}, rules)
+	assert.Equal(map[int][]int{}, alts)
+
+	second := NewGrammarSliceRecorder(p.BaseParser)
+	p.Stat()
+	rules, alts = second.UsedGrammarSlice()
+	firstRules, firstAlts := first.UsedGrammarSlice()
+	assert.Equal(rules, firstRules)
+	assert.Equal(alts, firstAlts)
+	assert.Equal(true, len(predictions) > 0)
+}
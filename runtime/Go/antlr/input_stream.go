@@ -4,6 +4,15 @@
 
 package antlr
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"unicode/utf8"
+)
+
 type InputStream struct {
 	name  string
 	index int
@@ -23,6 +32,53 @@ func NewInputStream(data string) *InputStream {
 	return is
 }
 
+// utf8BOM is the byte order mark some editors write at the start of UTF-8
+// files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// NewInputStreamFromReader reads r to its end and returns a stream of the
+// UTF-8 encoded characters read, without a leading byte order mark. It
+// returns an error if reading fails or the input is not valid UTF-8.
+func NewInputStreamFromReader(r io.Reader) (*InputStream, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	buf = bytes.TrimPrefix(buf, utf8BOM)
+
+	data := make([]rune, 0, utf8.RuneCount(buf))
+	for i := 0; i < len(buf); {
+		c, size := utf8.DecodeRune(buf[i:])
+		if c == utf8.RuneError && size <= 1 {
+			return nil, fmt.Errorf("invalid UTF-8 encoding at byte offset %d", i)
+		}
+		data = append(data, c)
+		i += size
+	}
+
+	is := new(InputStream)
+	is.name = "<empty>"
+	is.data = data
+	is.size = len(data)
+	return is, nil
+}
+
+// NewInputStreamFromFile returns a stream of the characters of the UTF-8
+// encoded file at path, as NewInputStreamFromReader does.
+func NewInputStreamFromFile(path string) (*InputStream, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	is, err := NewInputStreamFromReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return is, nil
+}
+
 func (is *InputStream) reset() {
 	is.index = 0
 }
@@ -0,0 +1,134 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNewInputStreamFromReader(t *testing.T) {
+	assert := assertNew(t)
+	is, err := NewInputStreamFromReader(iotest.OneByteReader(strings.NewReader("\xEF\xBB\xBFdef é(x)")))
+	assert.Nil(err)
+	assert.Equal(8, is.Size())
+	assert.Equal('d', rune(is.LA(1)))
+	assert.Equal("def é(x)", is.String())
+
+	// a BOM is only stripped at the start
+	is, err = NewInputStreamFromReader(strings.NewReader("a\xEF\xBB\xBF"))
+	assert.Nil(err)
+	assert.Equal("a\uFEFF", is.String())
+}
+
+func TestNewInputStreamFromReaderEmpty(t *testing.T) {
+	assert := assertNew(t)
+	for _, input := range []string{"", "\xEF\xBB\xBF"} {
+		is, err := NewInputStreamFromReader(strings.NewReader(input))
+		assert.Nil(err)
+		assert.Equal(0, is.Size())
+		assert.Equal(TokenEOF, is.LA(1))
+		assert.Equal(TokenEOF, NewExprLexer(is).NextToken().GetTokenType())
+	}
+}
+
+func TestNewInputStreamFromReaderInvalid(t *testing.T) {
+	assert := assertNew(t)
+	is, err := NewInputStreamFromReader(strings.NewReader("ab\xC3("))
+	assert.Nil(is)
+	assert.Equal("invalid UTF-8 encoding at byte offset 2", err.Error())
+
+	// an encoded U+FFFD is valid
+	is, err = NewInputStreamFromReader(strings.NewReader("�"))
+	assert.Nil(err)
+	assert.Equal(0xFFFD, is.LA(1))
+
+	_, err = NewInputStreamFromReader(iotest.TimeoutReader(strings.NewReader("ab")))
+	assert.Equal(iotest.ErrTimeout, err)
+}
+
+func TestNewInputStreamFromFile(t *testing.T) {
+	assert := assertNew(t)
+	dir, err := ioutil.TempDir("", "antlr")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "f.expr")
+	assert.Nil(ioutil.WriteFile(path, []byte("\xEF\xBB\xBFdef f(x) { x; }"), 0644))
+	is, err := NewInputStreamFromFile(path)
+	assert.Nil(err)
+	assert.Equal("def f(x) { x; }", is.String())
+
+	bad := filepath.Join(dir, "bad.expr")
+	assert.Nil(ioutil.WriteFile(bad, []byte{'x', 0xFF}, 0644))
+	_, err = NewInputStreamFromFile(bad)
+	assert.Equal(bad+": invalid UTF-8 encoding at byte offset 1", err.Error())
+
+	_, err = NewInputStreamFromFile(filepath.Join(dir, "missing.expr"))
+	assert.NotNil(err)
+}
+
+func TestNewInputStreamFromBytes(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(ü) {\n  return ü * 2;\n}"
+	s := NewInputStreamFromBytes([]byte(input))
+
+	assert.Equal(int('d'), s.LA(1))
+	assert.Equal(int('ü'), s.LA(7))
+	assert.Equal(int(')'), s.LA(8))
+	for i := 0; i < 7; i++ {
+		s.Consume()
+	}
+	assert.Equal(8, s.Index()) // ü takes 2 bytes
+	assert.Equal(int('ü'), s.LA(-1))
+	assert.Equal(int('('), s.LA(-2))
+	assert.Equal(len(input), s.Size())
+	s.Seek(0)
+
+	expected := NewExprLexer(NewInputStream(input)).GetAllTokens()
+	tokens := NewExprLexer(s).GetAllTokens()
+	assert.Equal(len(expected), len(tokens))
+	for i, tok := range tokens {
+		assert.Equal(expected[i].GetTokenType(), tok.GetTokenType())
+		assert.Equal(expected[i].GetText(), tok.GetText())
+		assert.Equal(expected[i].GetLine(), tok.GetLine())
+		assert.Equal(expected[i].GetColumn(), tok.GetColumn())
+		// positions are byte offsets
+		assert.Equal(tok.GetText(), input[tok.GetStart():tok.GetStop()+1])
+	}
+	assert.Nil(s.Err())
+}
+
+func TestNewInputStreamFromBytesInvalid(t *testing.T) {
+	assert := assertNew(t)
+	s := NewInputStreamFromBytes([]byte("x = 1\xff2;"))
+	l := NewExprLexer(s)
+	l.RemoveErrorListeners()
+	listener := &parserTestErrorListener{DefaultErrorListener: NewDefaultErrorListener()}
+	l.AddErrorListener(listener)
+
+	tokens := l.GetAllTokens()
+	assert.Equal([]int{ExprLexerID, ExprLexerT__7, ExprLexerINT, ExprLexerINT, ExprLexerT__6}, lexerTestTokenTypes(tokens))
+	assert.Equal([]string{"token recognition error at: '\xff'"}, listener.messages)
+	assert.Equal(6, tokens[3].GetStart())
+	assert.Equal(8, s.Size())
+	assert.NotNil(s.Err())
+	assert.Equal("invalid UTF-8 encoding at byte offset 5", s.Err().Error())
+
+	// parsing reports the bad byte rather than stopping there
+	l = NewExprLexer(NewInputStreamFromBytes([]byte("def f(x) { x; }\xff y;")))
+	l.RemoveErrorListeners()
+	listener.messages = nil
+	l.AddErrorListener(listener)
+	p := NewExprParser(NewCommonTokenStream(l, TokenDefaultChannel))
+	p.RemoveErrorListeners()
+	p.Prog()
+	assert.Equal([]string{"token recognition error at: '\xff'"}, listener.messages)
+	assert.Equal("y", p.GetCurrentToken().GetText())
+}
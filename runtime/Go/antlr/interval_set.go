@@ -14,7 +14,21 @@ type Interval struct {
 	Stop  int
 }
 
-/* stop is not included! */
+// NewInterval creates the interval start..stop-1, stop is not included!
+//
+// An interval with stop <= start, such as TreeInvalidInterval, is empty:
+// it contains nothing, has length 0 and overlaps no other interval.
+//
+// Source intervals, as returned by GetSourceInterval, use the same type but
+// include stop: a terminal for token i has the interval i..i. Contains,
+// Length, Overlaps and Union treat stop as excluded and so give wrong
+// answers for source intervals; use SpanContains, SpanLength, SpanOverlaps
+// and SpanUnion for those, which treat an interval with stop < start as
+// empty.
+//
+// NewInterval accepts any bounds: inverted intervals are the sentinels of
+// missing spans and IntervalSets hold negative token types such as
+// TokenEpsilon, so no start and stop can be rejected.
 func NewInterval(start, stop int) *Interval {
 	i := new(Interval)
 
@@ -23,10 +37,73 @@ func NewInterval(start, stop int) *Interval {
 	return i
 }
 
+// Contains reports whether item is in i, stop excluded. Use SpanContains for
+// source intervals.
 func (i *Interval) Contains(item int) bool {
 	return item >= i.Start && item < i.Stop
 }
 
+// Length returns the number of elements in i, stop excluded, 0 for an empty
+// interval. Use SpanLength for source intervals.
+func (i *Interval) Length() int {
+	if i.Stop <= i.Start {
+		return 0
+	}
+	return i.Stop - i.Start
+}
+
+// Overlaps reports whether i and other have at least one element in common,
+// stops excluded. Use SpanOverlaps for source intervals.
+func (i *Interval) Overlaps(other *Interval) bool {
+	return i.Length() > 0 && other.Length() > 0 && i.Start < other.Stop && other.Start < i.Stop
+}
+
+// Union returns the smallest interval containing both i and other, stops
+// excluded. An empty interval adds nothing, so the union with an empty
+// interval is a copy of the other one. Use SpanUnion for source intervals.
+func (i *Interval) Union(other *Interval) *Interval {
+	if other.Length() == 0 {
+		return NewInterval(i.Start, i.Stop)
+	}
+	if i.Length() == 0 {
+		return NewInterval(other.Start, other.Stop)
+	}
+	return NewInterval(intMin(i.Start, other.Start), intMax(i.Stop, other.Stop))
+}
+
+// SpanContains reports whether item is in the source interval i, stop
+// included.
+func (i *Interval) SpanContains(item int) bool {
+	return item >= i.Start && item <= i.Stop
+}
+
+// SpanLength returns the number of elements in the source interval i, stop
+// included, 0 if stop < start as for TreeInvalidInterval.
+func (i *Interval) SpanLength() int {
+	if i.Stop < i.Start {
+		return 0
+	}
+	return i.Stop - i.Start + 1
+}
+
+// SpanOverlaps reports whether the source intervals i and other have at
+// least one element in common.
+func (i *Interval) SpanOverlaps(other *Interval) bool {
+	return i.SpanLength() > 0 && other.SpanLength() > 0 && i.Start <= other.Stop && other.Start <= i.Stop
+}
+
+// SpanUnion returns the smallest source interval containing both i and
+// other, treating an empty interval like Union does.
+func (i *Interval) SpanUnion(other *Interval) *Interval {
+	if other.SpanLength() == 0 {
+		return NewInterval(i.Start, i.Stop)
+	}
+	if i.SpanLength() == 0 {
+		return NewInterval(other.Start, other.Stop)
+	}
+	return NewInterval(intMin(i.Start, other.Start), intMax(i.Stop, other.Stop))
+}
+
 func (i *Interval) String() string {
 	if i.Start == i.Stop-1 {
 		return strconv.Itoa(i.Start)
@@ -126,6 +203,74 @@ func (i *IntervalSet) complement(start int, stop int) *IntervalSet {
 	return result
 }
 
+// And returns a new set holding the elements that are in both i and other.
+func (i *IntervalSet) And(other *IntervalSet) *IntervalSet {
+	result := NewIntervalSet()
+	if other == nil {
+		return result
+	}
+	a, b := i.intervals, other.intervals
+	for j, k := 0, 0; j < len(a) && k < len(b); {
+		result.appendInterval(intMax(a[j].Start, b[k].Start), intMin(a[j].Stop, b[k].Stop))
+		// advance whichever interval ends first
+		if a[j].Stop < b[k].Stop {
+			j++
+		} else {
+			k++
+		}
+	}
+	return result
+}
+
+// Subtract returns a new set holding the elements of i that are not in
+// other.
+func (i *IntervalSet) Subtract(other *IntervalSet) *IntervalSet {
+	result := NewIntervalSet()
+	var b []*Interval
+	if other != nil {
+		b = other.intervals
+	}
+	k := 0
+	for _, v := range i.intervals {
+		// intervals of other that end before v can't affect v or any
+		// later interval of i
+		for k < len(b) && b[k].Stop <= v.Start {
+			k++
+		}
+		start := v.Start
+		for m := k; m < len(b) && b[m].Start < v.Stop; m++ {
+			result.appendInterval(start, b[m].Start)
+			start = intMax(start, b[m].Stop)
+		}
+		result.appendInterval(start, v.Stop)
+	}
+	return result
+}
+
+// Complement returns a new set holding the elements of
+// minElement..maxElement, both included, that are not in i.
+func (i *IntervalSet) Complement(minElement, maxElement int) *IntervalSet {
+	all := NewIntervalSet()
+	all.appendInterval(minElement, maxElement+1)
+	return all.Subtract(i)
+}
+
+// appendInterval adds start..stop-1 to the end of i, coalescing it with the
+// last interval if they overlap or are adjacent. The caller must add
+// intervals in order of their start. Empty intervals are ignored.
+func (i *IntervalSet) appendInterval(start, stop int) {
+	if start >= stop {
+		return
+	}
+	if n := len(i.intervals); n > 0 && start <= i.intervals[n-1].Stop {
+		if last := i.intervals[n-1]; stop > last.Stop {
+			i.intervals[n-1] = NewInterval(last.Start, stop)
+		}
+		return
+	}
+	i.intervals = append(i.intervals, NewInterval(start, stop))
+}
+
 func (i *IntervalSet) contains(item int) bool {
 	if i.intervals == nil {
 		return false
@@ -0,0 +1,175 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+// newTestIntervalSet creates a set from inclusive start, stop pairs.
+func newTestIntervalSet(ranges ...int) *IntervalSet {
+	s := NewIntervalSet()
+	for k := 0; k < len(ranges); k += 2 {
+		s.addRange(ranges[k], ranges[k+1])
+	}
+	return s
+}
+
+func TestIntervalSetAnd(t *testing.T) {
+	assert := assertNew(t)
+
+	// overlapping
+	assert.Equal("{3..5, 10}", newTestIntervalSet(1, 5, 8, 10).And(newTestIntervalSet(3, 7, 10, 12)).String())
+	// full overlap
+	assert.Equal("2..4", newTestIntervalSet(1, 10).And(newTestIntervalSet(2, 4)).String())
+	assert.Equal("2..4", newTestIntervalSet(2, 4).And(newTestIntervalSet(1, 10)).String())
+	// adjacent but disjoint
+	assert.Equal("{}", newTestIntervalSet(1, 2).And(newTestIntervalSet(3, 4)).String())
+	// disjoint
+	assert.Equal("{}", newTestIntervalSet(1, 2, 10, 12).And(newTestIntervalSet(5, 7)).String())
+	// one interval spanning several
+	assert.Equal("{1..2, 5..6, 9}", newTestIntervalSet(1, 2, 5, 6, 9, 12).And(newTestIntervalSet(0, 9)).String())
+	// empty operands
+	assert.Equal("{}", NewIntervalSet().And(newTestIntervalSet(1, 2)).String())
+	assert.Equal("{}", newTestIntervalSet(1, 2).And(NewIntervalSet()).String())
+	assert.Equal("{}", newTestIntervalSet(1, 2).And(nil).String())
+}
+
+func TestIntervalSetSubtract(t *testing.T) {
+	assert := assertNew(t)
+
+	// overlapping
+	assert.Equal("{1..2, 8..9}", newTestIntervalSet(1, 5, 8, 10).Subtract(newTestIntervalSet(3, 7, 10, 12)).String())
+	// split in the middle
+	assert.Equal("{1, 5..10}", newTestIntervalSet(1, 10).Subtract(newTestIntervalSet(2, 4)).String())
+	// full overlap
+	assert.Equal("{}", newTestIntervalSet(2, 4).Subtract(newTestIntervalSet(1, 10)).String())
+	// adjacent but disjoint
+	assert.Equal("1..2", newTestIntervalSet(1, 2).Subtract(newTestIntervalSet(3, 4)).String())
+	assert.Equal("3..4", newTestIntervalSet(3, 4).Subtract(newTestIntervalSet(1, 2)).String())
+	// several holes in one interval
+	assert.Equal("{0, 3, 5, 7..9}", newTestIntervalSet(0, 9).Subtract(newTestIntervalSet(1, 2, 4, 4, 6, 6)).String())
+	// empty operands
+	assert.Equal("{}", NewIntervalSet().Subtract(newTestIntervalSet(1, 2)).String())
+	assert.Equal("1..2", newTestIntervalSet(1, 2).Subtract(NewIntervalSet()).String())
+	assert.Equal("1..2", newTestIntervalSet(1, 2).Subtract(nil).String())
+}
+
+func TestIntervalSetComplement(t *testing.T) {
+	assert := assertNew(t)
+
+	assert.Equal("{1..2, 6..7, 11..20}", newTestIntervalSet(3, 5, 8, 10).Complement(1, 20).String())
+	// adjacent intervals leave no gap
+	assert.Equal("{1, 6}", newTestIntervalSet(2, 3, 4, 5).Complement(1, 6).String())
+	// set covering the whole range
+	assert.Equal("{}", newTestIntervalSet(0, 30).Complement(1, 20).String())
+	// disjoint from the range
+	assert.Equal("1..5", newTestIntervalSet(10, 12).Complement(1, 5).String())
+	// empty set
+	assert.Equal("1..5", NewIntervalSet().Complement(1, 5).String())
+}
+
+func TestIntervalSetAlgebraCoalesces(t *testing.T) {
+	assert := assertNew(t)
+
+	s := newTestIntervalSet(1, 3, 7, 9).Complement(0, 10).Complement(0, 10)
+	assert.Equal("{1..3, 7..9}", s.String())
+	assert.Equal(2, len(s.intervals))
+
+	// 4..6 removed from 1..9 and complemented again gives back one interval
+	s = newTestIntervalSet(4, 6).Complement(1, 9).Complement(1, 9)
+	assert.Equal([]*Interval{NewInterval(4, 7)}, s.intervals)
+}
+
+func TestIntervalContains(t *testing.T) {
+	assert := assertNew(t)
+	i := NewInterval(3, 6)
+
+	assert.Equal(false, i.Contains(2))
+	assert.Equal(true, i.Contains(3))
+	assert.Equal(true, i.Contains(5))
+	assert.Equal(false, i.Contains(6))
+
+	assert.Equal(false, NewInterval(4, 4).Contains(4))
+	assert.Equal(false, TreeInvalidInterval.Contains(-1))
+	assert.Equal(false, TreeInvalidInterval.Contains(-2))
+}
+
+func TestIntervalLength(t *testing.T) {
+	assert := assertNew(t)
+
+	assert.Equal(3, NewInterval(3, 6).Length())
+	assert.Equal(1, NewInterval(3, 4).Length())
+	assert.Equal(0, NewInterval(3, 3).Length())
+	assert.Equal(0, NewInterval(6, 3).Length())
+	assert.Equal(0, TreeInvalidInterval.Length())
+}
+
+func TestIntervalOverlaps(t *testing.T) {
+	assert := assertNew(t)
+	i := NewInterval(3, 6)
+
+	assert.Equal(true, i.Overlaps(NewInterval(5, 9)))
+	assert.Equal(true, i.Overlaps(NewInterval(0, 4)))
+	assert.Equal(true, i.Overlaps(NewInterval(4, 5)))
+	assert.Equal(true, i.Overlaps(NewInterval(0, 9)))
+	// adjacent
+	assert.Equal(false, i.Overlaps(NewInterval(6, 9)))
+	assert.Equal(false, i.Overlaps(NewInterval(0, 3)))
+	// empty
+	assert.Equal(false, i.Overlaps(NewInterval(4, 4)))
+	assert.Equal(false, i.Overlaps(NewInterval(5, 4)))
+	assert.Equal(false, i.Overlaps(TreeInvalidInterval))
+	assert.Equal(false, TreeInvalidInterval.Overlaps(TreeInvalidInterval))
+}
+
+func TestIntervalUnion(t *testing.T) {
+	assert := assertNew(t)
+	i := NewInterval(3, 6)
+
+	assert.Equal(NewInterval(3, 9), i.Union(NewInterval(5, 9)))
+	assert.Equal(NewInterval(0, 6), i.Union(NewInterval(0, 4)))
+	assert.Equal(NewInterval(3, 6), i.Union(NewInterval(4, 5)))
+	// disjoint intervals are spanned
+	assert.Equal(NewInterval(3, 12), i.Union(NewInterval(10, 12)))
+	// empty
+	assert.Equal(NewInterval(3, 6), i.Union(TreeInvalidInterval))
+	assert.Equal(NewInterval(3, 6), TreeInvalidInterval.Union(i))
+	assert.Equal(NewInterval(3, 6), NewInterval(20, 10).Union(i))
+	assert.Equal(0, TreeInvalidInterval.Union(TreeInvalidInterval).Length())
+
+	// the operands are not modified
+	assert.Equal(NewInterval(3, 6), i)
+}
+
+func TestIntervalSpans(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("x;").Stat()
+	x := tree.GetChild(0).(ParseTree).GetSourceInterval()
+	semi := tree.GetChild(1).(ParseTree).GetSourceInterval()
+	stat := tree.GetSourceInterval()
+	assert.Equal(NewInterval(1, 1), semi)
+
+	assert.Equal(1, semi.SpanLength())
+	assert.Equal(true, semi.SpanContains(1))
+	assert.Equal(false, semi.SpanContains(0))
+	assert.Equal(true, semi.SpanOverlaps(semi))
+	assert.Equal(false, semi.SpanOverlaps(x))
+	assert.Equal(true, semi.SpanOverlaps(stat))
+	assert.Equal(2, stat.SpanLength())
+	assert.Equal(stat, x.SpanUnion(semi))
+
+	// the half-open helpers do not apply to source intervals
+	assert.Equal(0, semi.Length())
+
+	// inverted intervals are empty
+	assert.Equal(0, TreeInvalidInterval.SpanLength())
+	assert.Equal(false, TreeInvalidInterval.SpanContains(-1))
+	assert.Equal(false, TreeInvalidInterval.SpanOverlaps(TreeInvalidInterval))
+	assert.Equal(false, stat.SpanOverlaps(NewInterval(1, 0)))
+	assert.Equal(stat, stat.SpanUnion(TreeInvalidInterval))
+	assert.Equal(stat, TreeInvalidInterval.SpanUnion(stat))
+	assert.Equal(0, TreeInvalidInterval.SpanUnion(TreeInvalidInterval).SpanLength())
+}
@@ -26,6 +26,7 @@ type Lexer interface {
 	PopMode() int
 	SetType(int)
 	SetMode(int)
+	SeekTo(charIndex, line, column int)
 }
 
 type BaseLexer struct {
@@ -48,6 +49,9 @@ type BaseLexer struct {
 	modeStack              IntStack
 	mode                   int
 	text                   string
+	invalidTokenPolicy     int
+	pendingTokens          []Token
+	tieBreaker             func(candidates []int) int
 }
 
 func NewBaseLexer(input CharStream) *BaseLexer {
@@ -111,6 +115,14 @@ const (
 	LexerSkip        = -3
 )
 
+// Policies for tokens emitted by a lexer rule with type TokenEOF, see
+// SetInvalidTokenPolicy.
+const (
+	LexerInvalidTokenPassThrough = 0
+	LexerInvalidTokenDrop        = 1
+	LexerInvalidTokenError       = 2
+)
+
 const (
 	LexerDefaultTokenChannel = TokenDefaultChannel
 	LexerHidden              = TokenHiddenChannel
@@ -124,6 +136,7 @@ func (b *BaseLexer) reset() {
 		b.input.Seek(0) // rewind the input
 	}
 	b.token = nil
+	b.pendingTokens = nil
 	b.thetype = TokenInvalidType
 	b.channel = TokenDefaultChannel
 	b.TokenStartCharIndex = -1
@@ -138,6 +151,26 @@ func (b *BaseLexer) reset() {
 	b.Interpreter.reset()
 }
 
+// SeekTo moves the lexer to character charIndex of its input, which is on
+// the given line, counting from 1, at the given column, counting from 0, so
+// that the next call to NextToken matches a token starting there. Tokens
+// emitted but not returned yet are discarded. The input stream and the
+// lexer mode are kept, which allows re-lexing a region of the input.
+func (b *BaseLexer) SeekTo(charIndex, line, column int) {
+	b.input.Seek(charIndex)
+	b.token = nil
+	b.pendingTokens = nil
+	b.thetype = TokenInvalidType
+	b.channel = TokenDefaultChannel
+	b.TokenStartCharIndex = -1
+	b.TokenStartColumn = -1
+	b.TokenStartLine = -1
+	b.text = ""
+	b.hitEOF = false
+
+	b.Interpreter.resetAt(line, column)
+}
+
 func (b *BaseLexer) GetInterpreter() ILexerATNSimulator {
 	return b.Interpreter
 }
@@ -162,6 +195,12 @@ func (b *BaseLexer) setTokenFactory(f TokenFactory) {
 	b.factory = f
 }
 
+// SetTokenFactory sets the factory used to create the tokens the lexer
+// emits, for example a PooledTokenFactory.
+func (b *BaseLexer) SetTokenFactory(f TokenFactory) {
+	b.setTokenFactory(f)
+}
+
 func (b *BaseLexer) safeMatch() (ret int) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -169,6 +208,8 @@ func (b *BaseLexer) safeMatch() (ret int) {
 				b.notifyListeners(re) // Report error
 				b.Recover(re)
 				ret = LexerSkip // default
+			} else if te, ok := e.(*lexerTieBreakerError); ok {
+				panic(te)
 			}
 		}
 	}()
@@ -192,9 +233,12 @@ func (b *BaseLexer) NextToken() Token {
 	}()
 
 	for {
+		if len(b.pendingTokens) > 0 {
+			return b.nextPendingToken()
+		}
 		if b.hitEOF {
 			b.EmitEOF()
-			return b.token
+			return b.nextPendingToken()
 		}
 		b.token = nil
 		b.channel = TokenDefaultChannel
@@ -230,7 +274,9 @@ func (b *BaseLexer) NextToken() Token {
 		if b.token == nil {
 			b.Virt.Emit()
 		}
-		return b.token
+		if b.invalidTokenPolicy != LexerInvalidTokenPassThrough {
+			b.applyInvalidTokenPolicy()
+		}
 	}
 
 	return nil
@@ -250,6 +296,40 @@ func (b *BaseLexer) More() {
 	b.thetype = LexerMore
 }
 
+// SetInvalidTokenPolicy sets how NextToken handles a token that a lexer rule
+// emits with type -1 (for example through SetType or an overridden Emit).
+// Such a token would otherwise reach the parser looking like the end of
+// input.
+//
+// LexerInvalidTokenPassThrough, the default, returns the token unchanged.
+// LexerInvalidTokenDrop discards it and continues with the next token.
+// LexerInvalidTokenError replaces it with a copy of type TokenInvalidType,
+// which no parser rule Matches, so the parser reports it and adds it to the
+// tree as an error node.
+//
+// The EOF token emitted at the end of the input is never affected.
+func (b *BaseLexer) SetInvalidTokenPolicy(policy int) {
+	b.invalidTokenPolicy = policy
+}
+
+// SetTieBreaker sets a function choosing the rule of a token when several
+// lexer rules match the longest input. It is called with the indexes of
+// those rules in order of priority, the order of the rules in the grammar,
+// and returns the index of the rule to use, whose type and actions make the
+// token. The lexer panics if it returns another index. Without a tie
+// breaker, the default, the first candidate is used, so keywords defined
+// before an identifier rule win over it.
+//
+// Only ties between rules that end at the same character are reported; a
+// longer match always wins.
+func (b *BaseLexer) SetTieBreaker(tieBreaker func(candidates []int) int) {
+	b.tieBreaker = tieBreaker
+}
+
+func (b *BaseLexer) getTieBreaker() func(candidates []int) int {
+	return b.tieBreaker
+}
+
 func (b *BaseLexer) SetMode(m int) {
 	b.mode = m
 }
@@ -291,13 +371,44 @@ func (b *BaseLexer) GetTokenSourceCharStreamPair() *TokenSourceCharStreamPair {
 	return b.tokenFactorySourcePair
 }
 
-// By default does not support multiple emits per NextToken invocation
-// for efficiency reasons. Subclass and override l method, NextToken,
-// and GetToken (to push tokens into a list and pull from that list
-// rather than a single variable as l implementation does).
-// /
+// EmitToken queues token to be returned by NextToken. A lexer rule action,
+// or an overridden Emit, may call it several times for a single match, for
+// example to split one lexeme into several tokens. NextToken then returns
+// the queued tokens one per call, in the order they were emitted, before
+// it matches any more input.
+//
+// The queued tokens are returned as they are, so their text, channel and
+// positions should be those of the part of the lexeme each one covers.
+// Token indexes are assigned by the token stream as it fetches them.
 func (b *BaseLexer) EmitToken(token Token) {
 	b.token = token
+	b.pendingTokens = append(b.pendingTokens, token)
+}
+
+func (b *BaseLexer) nextPendingToken() Token {
+	t := b.pendingTokens[0]
+	b.pendingTokens[0] = nil
+	b.pendingTokens = b.pendingTokens[1:]
+	return t
+}
+
+// applyInvalidTokenPolicy applies the invalid token policy to the tokens
+// emitted by the last match.
+func (b *BaseLexer) applyInvalidTokenPolicy() {
+	tokens := b.pendingTokens[:0]
+	for _, t := range b.pendingTokens {
+		if t.GetTokenType() == TokenEOF {
+			if b.invalidTokenPolicy == LexerInvalidTokenDrop {
+				continue
+			}
+			t = b.factory.Create(t.GetSource(), TokenInvalidType, t.GetText(), t.GetChannel(), t.GetStart(), t.GetStop(), t.GetLine(), t.GetColumn())
+		}
+		tokens = append(tokens, t)
+	}
+	for i := len(tokens); i < len(b.pendingTokens); i++ {
+		b.pendingTokens[i] = nil
+	}
+	b.pendingTokens = tokens
 }
 
 // The standard method called to automatically emit a token at the
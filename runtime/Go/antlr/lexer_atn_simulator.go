@@ -24,6 +24,7 @@ type ILexerATNSimulator interface {
 	IATNSimulator
 
 	reset()
+	resetAt(line, charPositionInLine int)
 	Match(input CharStream, mode int) int
 	GetCharPositionInLine() int
 	GetLine() int
@@ -106,6 +107,14 @@ func (l *LexerATNSimulator) reset() {
 	l.mode = LexerDefaultMode
 }
 
+// resetAt resets the simulator like reset, but for input starting at the
+// given line and position in that line.
+func (l *LexerATNSimulator) resetAt(line, charPositionInLine int) {
+	l.reset()
+	l.Line = line
+	l.CharPositionInLine = charPositionInLine
+}
+
 func (l *LexerATNSimulator) MatchATN(input CharStream) int {
 	startState := l.atn.modeToStartState[l.mode]
 
@@ -247,8 +256,12 @@ func (l *LexerATNSimulator) computeTargetState(input CharStream, s *DFAState, t
 func (l *LexerATNSimulator) failOrAccept(prevAccept *SimState, input CharStream, reach ATNConfigSet, t int) int {
 	if l.prevAccept.dfaState != nil {
 		lexerActionExecutor := prevAccept.dfaState.lexerActionExecutor
+		prediction := prevAccept.dfaState.prediction
+		if tb, ok := l.recog.(lexerTieBreaking); ok && tb.getTieBreaker() != nil {
+			lexerActionExecutor, prediction = l.breakTie(tb.getTieBreaker(), prevAccept.dfaState, lexerActionExecutor, prediction)
+		}
 		l.accept(input, lexerActionExecutor, l.startIndex, prevAccept.index, prevAccept.line, prevAccept.column)
-		return prevAccept.dfaState.prediction
+		return prediction
 	}
 
 	// if no accept and EOF is first char, return EOF
@@ -259,6 +272,54 @@ func (l *LexerATNSimulator) failOrAccept(prevAccept *SimState, input CharStream,
 	panic(NewLexerNoViableAltException(l.recog, input, l.startIndex, reach))
 }
 
+// lexerTieBreaking is implemented by lexers that choose between rules
+// matching the same input, see BaseLexer.SetTieBreaker.
+type lexerTieBreaking interface {
+	getTieBreaker() func(candidates []int) int
+}
+
+// breakTie lets tieBreaker choose among the rules accepted by the accept
+// state s, if there are several, and returns the actions and token type of
+// the chosen rule. The DFA state itself keeps the default choice, the first
+// rule, since it is shared with other lexers.
+func (l *LexerATNSimulator) breakTie(tieBreaker func(candidates []int) int, s *DFAState,
+	lexerActionExecutor *LexerActionExecutor, prediction int) (*LexerActionExecutor, int) {
+
+	candidates := make([]int, 0)
+	executors := make(map[int]*LexerActionExecutor)
+	for _, cfg := range s.configs.GetItems() {
+		if _, ok := cfg.GetState().(*RuleStopState); !ok {
+			continue
+		}
+		ruleIndex := cfg.GetState().GetRuleIndex()
+		if _, ok := executors[ruleIndex]; !ok {
+			candidates = append(candidates, ruleIndex)
+			executors[ruleIndex] = cfg.(*LexerATNConfig).lexerActionExecutor
+		}
+	}
+	if len(candidates) < 2 {
+		return lexerActionExecutor, prediction
+	}
+	chosen := tieBreaker(candidates)
+	executor, ok := executors[chosen]
+	if !ok {
+		panic(&lexerTieBreakerError{chosen: chosen, candidates: candidates})
+	}
+	return executor, l.atn.ruleToTokenType[chosen]
+}
+
+// lexerTieBreakerError is panicked when a tie breaker chooses a rule that is
+// not a candidate. Unlike other panics raised while matching, it is not
+// swallowed by the lexer.
+type lexerTieBreakerError struct {
+	chosen     int
+	candidates []int
+}
+
+func (e *lexerTieBreakerError) Error() string {
+	return fmt.Sprintf("tie breaker chose rule %d, which is not one of the candidates %v", e.chosen, e.candidates)
+}
+
 // Given a starting configuration set, figure out all ATN configurations
 // we can reach upon input {@code t}. Parameter {@code reach} is a return
 // parameter.
@@ -0,0 +1,192 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+// lexerTestInvalidLexer emits 'return' keywords with type TokenEOF.
+type lexerTestInvalidLexer struct {
+	*ExprLexer
+}
+
+func newLexerTestInvalidLexer(input string) *lexerTestInvalidLexer {
+	l := &lexerTestInvalidLexer{NewExprLexer(NewInputStream(input))}
+	l.Virt = l
+	return l
+}
+
+func (l *lexerTestInvalidLexer) Emit() Token {
+	if l.thetype == ExprLexerRETURN {
+		l.SetType(TokenEOF)
+	}
+	return l.BaseLexer.Emit()
+}
+
+// lexerTestSplitLexer emits every identifier as one ID token per letter,
+// the hidden channel for upper case letters.
+type lexerTestSplitLexer struct {
+	*ExprLexer
+}
+
+func newLexerTestSplitLexer(input string) *lexerTestSplitLexer {
+	l := &lexerTestSplitLexer{NewExprLexer(NewInputStream(input))}
+	l.Virt = l
+	return l
+}
+
+func (l *lexerTestSplitLexer) Emit() Token {
+	if l.thetype != ExprLexerID {
+		return l.BaseLexer.Emit()
+	}
+	var t Token
+	for i, c := range l.GetText() {
+		channel := TokenDefaultChannel
+		if c >= 'A' && c <= 'Z' {
+			channel = TokenHiddenChannel
+		}
+		start := l.TokenStartCharIndex + i
+		t = l.factory.Create(l.tokenFactorySourcePair, ExprLexerID, "", channel, start, start, l.TokenStartLine, l.TokenStartColumn+i)
+		l.EmitToken(t)
+	}
+	return t
+}
+
+func lexerTestNextTokens(l Lexer) []Token {
+	var tokens []Token
+	for {
+		t := l.NextToken()
+		tokens = append(tokens, t)
+		if t.GetTokenType() == TokenEOF && t.GetText() == "<EOF>" {
+			return tokens
+		}
+	}
+}
+
+func lexerTestTokenTypes(tokens []Token) []int {
+	types := make([]int, len(tokens))
+	for i, t := range tokens {
+		types[i] = t.GetTokenType()
+	}
+	return types
+}
+
+func TestLexerInvalidTokenPassThrough(t *testing.T) {
+	assert := assertNew(t)
+	l := newLexerTestInvalidLexer("x return 1")
+
+	tokens := lexerTestNextTokens(l)
+	assert.Equal([]int{ExprLexerID, TokenEOF, ExprLexerINT, TokenEOF}, lexerTestTokenTypes(tokens))
+	assert.Equal("return", tokens[1].GetText())
+}
+
+func TestLexerInvalidTokenDrop(t *testing.T) {
+	assert := assertNew(t)
+	l := newLexerTestInvalidLexer("x return 1 return")
+	l.SetInvalidTokenPolicy(LexerInvalidTokenDrop)
+
+	tokens := lexerTestNextTokens(l)
+	assert.Equal([]int{ExprLexerID, ExprLexerINT, TokenEOF}, lexerTestTokenTypes(tokens))
+}
+
+func TestLexerInvalidTokenError(t *testing.T) {
+	assert := assertNew(t)
+	l := newLexerTestInvalidLexer("x return 1")
+	l.SetInvalidTokenPolicy(LexerInvalidTokenError)
+
+	tokens := lexerTestNextTokens(l)
+	assert.Equal([]int{ExprLexerID, TokenInvalidType, ExprLexerINT, TokenEOF}, lexerTestTokenTypes(tokens))
+	assert.Equal("return", tokens[1].GetText())
+	assert.Equal(2, tokens[1].GetStart())
+	assert.Equal(7, tokens[1].GetStop())
+
+	p := NewExprParser(NewCommonTokenStream(newLexerTestInvalidLexer("def f(x) { return x; }"), TokenDefaultChannel))
+	p.RemoveErrorListeners()
+	l2 := p.GetTokenStream().GetTokenSource().(*lexerTestInvalidLexer)
+	l2.SetInvalidTokenPolicy(LexerInvalidTokenError)
+	tree := p.Prog()
+	assert.Equal(1, p.GetNumberOfSyntaxErrors())
+	assert.Equal(1, len(TreesFindAllTokenNodes(tree, TokenInvalidType)))
+}
+
+func TestLexerEmitTokenQueue(t *testing.T) {
+	assert := assertNew(t)
+	ts := NewCommonTokenStream(newLexerTestSplitLexer("aB 1\n  cd"), TokenDefaultChannel)
+	ts.Fill()
+
+	tokens := ts.GetAllTokens()
+	assert.Equal([]int{ExprLexerID, ExprLexerID, ExprLexerINT, ExprLexerID, ExprLexerID, TokenEOF}, lexerTestTokenTypes(tokens))
+	texts := make([]string, len(tokens))
+	for i, tok := range tokens {
+		assert.Equal(i, tok.GetTokenIndex())
+		texts[i] = tok.GetText()
+	}
+	assert.Equal([]string{"a", "B", "1", "c", "d", "<EOF>"}, texts)
+	assert.Equal(TokenHiddenChannel, tokens[1].GetChannel())
+	assert.Equal(TokenDefaultChannel, tokens[3].GetChannel())
+
+	assert.Equal([]int{0, 1, 3, 7, 8}, []int{tokens[0].GetStart(), tokens[1].GetStart(), tokens[2].GetStart(), tokens[3].GetStart(), tokens[4].GetStart()})
+	assert.Equal([]int{1, 1, 1, 2, 2}, []int{tokens[0].GetLine(), tokens[1].GetLine(), tokens[2].GetLine(), tokens[3].GetLine(), tokens[4].GetLine()})
+	assert.Equal([]int{0, 1, 3, 2, 3}, []int{tokens[0].GetColumn(), tokens[1].GetColumn(), tokens[2].GetColumn(), tokens[3].GetColumn(), tokens[4].GetColumn()})
+}
+
+func TestLexerSeekTo(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(x) {\n  x = 12;\n  return x;\n}"
+	all := NewExprLexer(NewInputStream(input)).GetAllTokens()
+
+	l := NewExprLexer(NewInputStream(input))
+	for i := 0; i < 9; i++ {
+		l.NextToken()
+	}
+	// re-lex from the "x" starting line 2
+	l.SeekTo(13, 2, 2)
+	relexed := l.GetAllTokens()
+	assert.Equal(len(all)-6, len(relexed))
+	for i, tok := range relexed {
+		assert.Equal(all[i+6].(*CommonToken).String(), tok.(*CommonToken).String())
+	}
+
+	// seeking back after EOF
+	l.SeekTo(0, 1, 0)
+	assert.Equal(all[0].(*CommonToken).String(), l.NextToken().(*CommonToken).String())
+}
+
+func TestLexerSeekToDiscardsPendingTokens(t *testing.T) {
+	assert := assertNew(t)
+	l := newLexerTestSplitLexer("abc de")
+	assert.Equal("a", l.NextToken().GetText())
+	l.SeekTo(4, 1, 4)
+	tok := l.NextToken()
+	assert.Equal("d", tok.GetText())
+	assert.Equal(4, tok.GetStart())
+	assert.Equal(4, tok.GetColumn())
+	assert.Equal("e", l.NextToken().GetText())
+	assert.Equal(TokenEOF, l.NextToken().GetTokenType())
+}
+
+func TestLexerSetTieBreaker(t *testing.T) {
+	assert := assertNew(t)
+	input := "return x;"
+	assert.Equal([]int{ExprLexerRETURN, ExprLexerID, ExprLexerT__6, TokenEOF}, lexerTestTokenTypes(lexerTestNextTokens(NewExprLexer(NewInputStream(input)))))
+
+	l := NewExprLexer(NewInputStream(input))
+	var ties [][]int
+	l.SetTieBreaker(func(candidates []int) int {
+		ties = append(ties, candidates)
+		return candidates[len(candidates)-1]
+	})
+	assert.Equal([]int{ExprLexerID, ExprLexerID, ExprLexerT__6, TokenEOF}, lexerTestTokenTypes(lexerTestNextTokens(l)))
+	// 'return' matches the RETURN and ID rules, the other tokens only one
+	assert.Equal([][]int{{12, 13}}, ties)
+
+	// the shared DFA keeps the default choice
+	assert.Equal([]int{ExprLexerRETURN, ExprLexerID, ExprLexerT__6, TokenEOF}, lexerTestTokenTypes(lexerTestNextTokens(NewExprLexer(NewInputStream(input)))))
+
+	l = NewExprLexer(NewInputStream(input))
+	l.SetTieBreaker(func(candidates []int) int { return 0 })
+	assert.Panics(func() { l.NextToken() })
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"time"
+)
+
+// DecisionInfo holds the profiling data collected for a single decision
+// by the ProfilingATNSimulator.
+//
+// Lookahead is measured in tokens, from the token where prediction started
+// up to and including the last token examined. The SLL counters cover every
+// prediction; the LL counters only those predictions that fell back to full
+// context.
+type DecisionInfo struct {
+	Decision int
+
+	// The number of times AdaptivePredict was invoked for this decision.
+	Invocations int
+
+	// The total time spent in AdaptivePredict for this decision.
+	TimeInPrediction time.Duration
+
+	SLLTotalLook int
+	SLLMinLook   int
+	SLLMaxLook   int
+
+	LLTotalLook int
+	LLMinLook   int
+	LLMaxLook   int
+
+	// The number of edges followed in the DFA cache and the number of ATN
+	// transitions computed because the DFA had no edge yet.
+	SLLDFATransitions int
+	SLLATNTransitions int
+	LLATNTransitions  int
+
+	// The number of times SLL prediction hit a conflict and fell back to
+	// full context (LL) prediction.
+	LLFallback int
+
+	Ambiguities          int
+	ContextSensitivities int
+	Errors               int
+	PredicateEvals       int
+}
+
+func NewDecisionInfo(decision int) *DecisionInfo {
+	return &DecisionInfo{Decision: decision}
+}
+
+// ParseInfo gives access to the profiling data of a parser, see
+// Parser.SetProfile.
+type ParseInfo struct {
+	atnSimulator *ProfilingATNSimulator
+}
+
+func NewParseInfo(atnSimulator *ProfilingATNSimulator) *ParseInfo {
+	return &ParseInfo{atnSimulator: atnSimulator}
+}
+
+// GetDecisionInfo returns the profiling data of every decision, indexed by
+// decision number.
+func (p *ParseInfo) GetDecisionInfo() []*DecisionInfo {
+	return p.atnSimulator.GetDecisionInfo()
+}
+
+// GetLLDecisions returns the decisions that required full context
+// prediction at least once.
+func (p *ParseInfo) GetLLDecisions() []int {
+	LL := make([]int, 0)
+	for _, d := range p.atnSimulator.GetDecisionInfo() {
+		if d.LLFallback > 0 {
+			LL = append(LL, d.Decision)
+		}
+	}
+	return LL
+}
+
+// GetTotalTimeInPrediction returns the time spent in AdaptivePredict over
+// all decisions.
+func (p *ParseInfo) GetTotalTimeInPrediction() time.Duration {
+	var t time.Duration
+	for _, d := range p.atnSimulator.GetDecisionInfo() {
+		t += d.TimeInPrediction
+	}
+	return t
+}
+
+// GetTotalSLLLookaheadOps returns the number of tokens examined by SLL
+// prediction over all decisions.
+func (p *ParseInfo) GetTotalSLLLookaheadOps() int {
+	k := 0
+	for _, d := range p.atnSimulator.GetDecisionInfo() {
+		k += d.SLLTotalLook
+	}
+	return k
+}
+
+// GetTotalLLLookaheadOps returns the number of tokens examined by full
+// context prediction over all decisions.
+func (p *ParseInfo) GetTotalLLLookaheadOps() int {
+	k := 0
+	for _, d := range p.atnSimulator.GetDecisionInfo() {
+		k += d.LLTotalLook
+	}
+	return k
+}
+
+// GetDFASize returns the number of DFA states built so far over all
+// decisions.
+func (p *ParseInfo) GetDFASize() int {
+	n := 0
+	for _, dfa := range p.atnSimulator.DecisionToDFA() {
+		n += dfa.numStates()
+	}
+	return n
+}
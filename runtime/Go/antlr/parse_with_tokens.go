@@ -0,0 +1,38 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// ParseWithTokens lexes and parses source in one step. It creates the lexer
+// with newLexer and the parser with newParser, parses with startRule, then
+// returns the tree, every token of the input on any channel up to and
+// including EOF, and the syntax errors reported by the lexer and the parser
+// in the order they occurred. The input is lexed only once: the tokens are
+// those the parser read, completed up to EOF if it stopped early.
+//
+// Syntax errors are collected instead of being printed to the console. For
+// a generated MyParser:
+//
+//	tree, tokens, errs := ParseWithTokens(
+//		func(input CharStream) Lexer { return NewMyLexer(input) },
+//		func(input TokenStream) Parser { return NewMyParser(input) },
+//		source,
+//		func(p Parser) ParserRuleContext { return p.(*MyParser).Prog() })
+func ParseWithTokens(newLexer func(input CharStream) Lexer, newParser func(input TokenStream) Parser,
+	source string, startRule func(p Parser) ParserRuleContext) (ParserRuleContext, []Token, []SyntaxError) {
+
+	errs := NewCollectingErrorListener()
+
+	lexer := newLexer(NewInputStream(source))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errs)
+
+	stream := NewCommonTokenStream(lexer, TokenDefaultChannel)
+	parser := newParser(stream)
+	parser.RemoveErrorListeners()
+	parser.AddErrorListener(errs)
+
+	tree := startRule(parser)
+	return tree, stream.GetAllTokens(), errs.Errors()
+}
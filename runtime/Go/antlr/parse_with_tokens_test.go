@@ -0,0 +1,55 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func parseExprWithTokens(source string) (ParserRuleContext, []Token, []SyntaxError) {
+	return ParseWithTokens(
+		func(input CharStream) Lexer {
+			l := &exprCommentLexer{NewExprLexer(input)}
+			l.Virt = l
+			return l
+		},
+		func(input TokenStream) Parser { return NewExprParser(input) },
+		source,
+		func(p Parser) ParserRuleContext { return p.(*ExprParser).Prog() })
+}
+
+func TestParseWithTokens(t *testing.T) {
+	assert := assertNew(t)
+	tree, tokens, errs := parseExprWithTokens("// inc\ndef inc(x) {\n  return x+1; // done\n}")
+
+	assert.Equal(0, len(errs))
+	assert.Equal("(prog (func_ def inc ( (arg x) ) (body { (stat return (expr (expr (primary x)) + (expr (primary 1))) ;) })))",
+		tree.ToStringTree(exprParser_ruleNames, nil))
+
+	assert.Equal(15, len(tokens))
+	assert.Equal("// inc", tokens[0].GetText())
+	assert.Equal(exprCommentLexerCommentChannel, tokens[0].GetChannel())
+	assert.Equal("// done", tokens[12].GetText())
+	assert.Equal(exprCommentLexerCommentChannel, tokens[12].GetChannel())
+	assert.Equal(TokenEOF, tokens[14].GetTokenType())
+	for i, tok := range tokens {
+		assert.Equal(i, tok.GetTokenIndex())
+	}
+
+	// the terminals of the tree are the buffered tokens
+	assert.Equal(tokens[1], tree.GetStart())
+}
+
+func TestParseWithTokensErrors(t *testing.T) {
+	assert := assertNew(t)
+	tree, tokens, errs := parseExprWithTokens("def f(x) { x = # 1 }\ndef g(y) { y; } // end")
+
+	assert.NotNil(tree)
+	assert.Equal("// end", tokens[len(tokens)-2].GetText())
+	assert.Equal(2, len(errs))
+	assert.Equal("token recognition error at: '#'", errs[0].Msg)
+	assert.Equal(1, errs[1].Line)
+	assert.Equal("}", errs[1].OffendingText)
+}
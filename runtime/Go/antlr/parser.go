@@ -6,6 +6,7 @@ package antlr
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 )
 
@@ -38,6 +39,14 @@ type BaseParser struct {
 	Interpreter     *ParserATNSimulator
 	BuildParseTrees bool
 
+	// attachEOF makes ExitRule of the start rule add the EOF token to the
+	// tree, see SetAttachEOF.
+	attachEOF bool
+
+	// predicateObserver is called with the result of every semantic
+	// predicate evaluated during prediction, see SetPredicateObserver.
+	predicateObserver func(ruleIndex, predIndex int, result bool)
+
 	input           TokenStream
 	errHandler      ErrorStrategy
 	precedenceStack IntStack
@@ -312,6 +321,37 @@ func (p *BaseParser) GetInterpreter() *ParserATNSimulator {
 	return p.Interpreter
 }
 
+// SetProfile switches the parser to a ProfilingATNSimulator, which records
+// per decision statistics that GetParseInfo reports, or back to a plain
+// ParserATNSimulator. The DFA cache, prediction mode and the observers set
+// on the interpreter are kept either way. Profiling costs time on every prediction, so it is off by default.
+func (p *BaseParser) SetProfile(profile bool) {
+	interp := p.Interpreter
+	if profile {
+		if interp.profiler == nil {
+			p.Interpreter = NewProfilingATNSimulator(p).ParserATNSimulator
+		}
+	} else if interp.profiler != nil {
+		p.Interpreter = NewParserATNSimulator(interp.parser, interp.atn, interp.decisionToDFA, interp.sharedContextCache)
+		p.Interpreter.copySettings(interp)
+	}
+}
+
+// GetParseInfo returns the profiling data collected since SetProfile(true)
+// was called, or nil if the parser is not profiling.
+func (p *BaseParser) GetParseInfo() *ParseInfo {
+	if p.Interpreter.profiler != nil {
+		return NewParseInfo(p.Interpreter.profiler)
+	}
+	return nil
+}
+
+// GetATNSimulator returns the ATN simulator used for prediction, from which
+// the DFA of each decision can be obtained through DecisionToDFA.
+func (p *BaseParser) GetATNSimulator() *ParserATNSimulator {
+	return p.Interpreter
+}
+
 func (p *BaseParser) GetATN() *ATN {
 	return p.Interpreter.atn
 }
@@ -400,6 +440,51 @@ func (p *BaseParser) SetTokenStream(input TokenStream) {
 	p.input = input
 }
 
+// Reset rewinds the parser so that it can be reused to parse input. The
+// context stack, syntax error count, precedence stack and trace listener are
+// cleared, while the ATN simulator, its DFA cache, the error listeners and
+// the error strategy are kept, so a warmed up parser keeps its speed.
+//
+// The caller then invokes a start rule to begin parsing input.
+func (p *BaseParser) Reset(input TokenStream) {
+	p.input = input
+	p.reset()
+}
+
+// WithFreshState returns a new parser that shares the ATN and the DFA cache
+// of p but has its own context stack, precedence stack, error strategy,
+// syntax error count and prediction context cache. No token stream is set;
+// call SetTokenStream before parsing.
+//
+// The ATN is not modified after deserialization and the DFA cache guards
+// its updates with locks, so parsers created this way may parse concurrently,
+// one goroutine per parser. Each parser shares the DFA states computed by the
+// others. The error listeners of p are copied to the new parser and must be
+// safe for concurrent use; parse listeners are not copied.
+//
+// A generated parser wraps the result in its own type and points the
+// interpreter at it, so that its semantic predicates are evaluated:
+//
+//	q := &MyParser{BaseParser: p.WithFreshState()}
+//	q.Interpreter.SetParser(q)
+func (p *BaseParser) WithFreshState() *BaseParser {
+	q := NewBaseParser(nil)
+	q.listeners = make([]ErrorListener, len(p.listeners))
+	copy(q.listeners, p.listeners)
+	q.RuleNames = p.RuleNames
+	q.LiteralNames = p.LiteralNames
+	q.SymbolicNames = p.SymbolicNames
+	q.GrammarFileName = p.GrammarFileName
+	q.BuildParseTrees = p.BuildParseTrees
+	q.attachEOF = p.attachEOF
+
+	interp := p.Interpreter
+	q.Interpreter = NewParserATNSimulator(q, interp.atn, interp.decisionToDFA, NewPredictionContextCache())
+	q.Interpreter.SetPredictionMode(interp.GetPredictionMode())
+
+	return q
+}
+
 // Match needs to return the current input symbol, which gets put
 // into the label for the associated token ref e.g., x=ID.
 //
@@ -407,6 +492,12 @@ func (p *BaseParser) GetCurrentToken() Token {
 	return p.input.LT(1)
 }
 
+// GetNumberOfSyntaxErrors returns the number of syntax errors reported
+// during parsing since the parser was created or last reset.
+func (p *BaseParser) GetNumberOfSyntaxErrors() int {
+	return p._SyntaxErrors
+}
+
 func (p *BaseParser) NotifyErrorListeners(msg string, offendingToken Token, err RecognitionException) {
 	if offendingToken == nil {
 		offendingToken = p.GetCurrentToken()
@@ -466,8 +557,36 @@ func (p *BaseParser) EnterRule(localctx ParserRuleContext, state, ruleIndex int)
 	}
 }
 
+// SetAttachEOF sets whether the context of the start rule gets the EOF token
+// as its last child when the rule ends at the end of the input, as if the
+// rule matched EOF explicitly. This lets walkers handle the end of the input
+// the same way for any grammar. Nothing is added to a tree that already ends
+// with EOF, or when the rule stops before the end of the input. It has no
+// effect unless BuildParseTrees is set.
+func (p *BaseParser) SetAttachEOF(attach bool) {
+	p.attachEOF = attach
+}
+
+// SetPredicateObserver sets a function called each time adaptive prediction
+// evaluates a semantic predicate, with the rule and predicate indexes the
+// predicate has in Sempred and the result. Prediction may evaluate a
+// predicate several times for one decision, or not at all when the
+// lookahead alone decides, and predicates checked by the generated rule code
+// after prediction are not reported. Precedence predicates are not reported
+// either. A nil observer, the default, reports nothing.
+func (p *BaseParser) SetPredicateObserver(observer func(ruleIndex, predIndex int, result bool)) {
+	p.predicateObserver = observer
+}
+
+func (p *BaseParser) getPredicateObserver() func(ruleIndex, predIndex int, result bool) {
+	return p.predicateObserver
+}
+
 func (p *BaseParser) ExitRule() {
 	p.ctx.SetStop(p.input.LT(-1))
+	if p.attachEOF && p.BuildParseTrees && p.ctx.GetParent() == nil {
+		p.addEOFNode(p.ctx)
+	}
 	// trigger event on ctx, before it reverts to parent
 	if p.parseListeners != nil {
 		p.TriggerExitRuleEvent()
@@ -480,8 +599,27 @@ func (p *BaseParser) ExitRule() {
 	}
 }
 
+// addEOFNode adds the EOF token to ctx if it is next in the input and not
+// the last child of ctx already.
+func (p *BaseParser) addEOFNode(ctx ParserRuleContext) {
+	eof := p.input.LT(1)
+	if eof.GetTokenType() != TokenEOF {
+		return
+	}
+	if n := ctx.GetChildCount(); n > 0 {
+		if last, ok := ctx.GetChild(n - 1).(TerminalNode); ok && last.GetSymbol().GetTokenType() == TokenEOF {
+			return
+		}
+	}
+	node := ctx.AddTokenNode(eof)
+	for _, l := range p.parseListeners {
+		l.VisitTerminal(node)
+	}
+}
+
 func (p *BaseParser) EnterOuterAlt(localctx ParserRuleContext, altNum int) {
 	localctx.SetAltNumber(altNum)
+	localctx.GetBaseRuleContext().outerAlt = altNum
 	// if we have Newlocalctx, make sure we replace existing ctx
 	// that is previous child of parse tree
 	if p.BuildParseTrees && p.ctx != localctx {
@@ -556,6 +694,9 @@ func (p *BaseParser) UnrollRecursionContexts(parentCtx ParserRuleContext) {
 		// add return ctx into invoking rule's tree
 		parentCtx.AddChild(retCtx)
 	}
+	if p.attachEOF && p.BuildParseTrees && parentCtx == nil {
+		p.addEOFNode(retCtx)
+	}
 }
 
 func (p *BaseParser) GetInvokingContext(ruleIndex int) ParserRuleContext {
@@ -635,6 +776,75 @@ func (p *BaseParser) GetExpectedTokensWithinCurrentRule() *IntervalSet {
 	return atn.NextTokens(s, nil)
 }
 
+// ExpectedRulesAtPosition returns, in increasing order, the indexes of the
+// rules whose invocation may begin at the current token, LT(1), given the
+// current state and context, as a structural counterpart of
+// GetExpectedTokens for completion. These are the rules invoked from the
+// current state before another token is matched, directly or from within
+// each other, or after the current rule and its callers end, and the rules
+// that the parser already entered at the current token, such as the
+// enclosing rules of the state of a syntax error at the start of a rule.
+// Without an input or outside of a parse, such as before the first rule is
+// entered or after Reset, no rules are expected.
+func (p *BaseParser) ExpectedRulesAtPosition() []int {
+	if p.input == nil || p.Interpreter == nil || p.state < 0 || p.state >= len(p.Interpreter.atn.states) {
+		return make([]int, 0)
+	}
+	atn := p.Interpreter.atn
+	rules := make(map[int]bool)
+
+	current := p.GetCurrentToken()
+	for ctx := p.ctx; ctx != nil; {
+		if ctx.GetStart() == nil || ctx.GetStart().GetTokenIndex() != current.GetTokenIndex() {
+			break
+		}
+		rules[ctx.GetRuleIndex()] = true
+		parent, _ := ctx.GetParent().(ParserRuleContext)
+		ctx = parent
+	}
+
+	p.expectedRules(atn.states[p.state], nil, p.ctx, rules, make(map[string]bool))
+
+	result := make([]int, 0, len(rules))
+	for ruleIndex := range rules {
+		result = append(result, ruleIndex)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// expectedRules adds to rules the rules invoked from s without matching a
+// token, with the follow states of the rules entered so far on stack and ctx
+// the context to return to once the rule of s ends with an empty stack.
+func (p *BaseParser) expectedRules(s ATNState, stack []ATNState, ctx ParserRuleContext, rules map[int]bool, busy map[string]bool) {
+	key := lookaheadKey(s, stack)
+	if busy[key] || len(stack) > atnMaxKMaxDepth {
+		return
+	}
+	busy[key] = true
+
+	if _, ok := s.(*RuleStopState); ok {
+		if len(stack) > 0 {
+			p.expectedRules(stack[len(stack)-1], stack[:len(stack)-1], ctx, rules, busy)
+		} else if ctx != nil && ctx.GetInvokingState() >= 0 {
+			rt := p.Interpreter.atn.states[ctx.GetInvokingState()].GetTransitions()[0].(*RuleTransition)
+			parent, _ := ctx.GetParent().(ParserRuleContext)
+			p.expectedRules(rt.followState, nil, parent, rules, busy)
+		}
+		return
+	}
+	for _, t := range s.GetTransitions() {
+		if rt, ok := t.(*RuleTransition); ok {
+			rules[rt.ruleIndex] = true
+			next := make([]ATNState, len(stack), len(stack)+1)
+			copy(next, stack)
+			p.expectedRules(rt.getTarget(), append(next, rt.followState), ctx, rules, busy)
+		} else if t.getIsEpsilon() {
+			p.expectedRules(t.getTarget(), stack, ctx, rules, busy)
+		}
+	}
+}
+
 // Get a rule's index (i.e., {@code RULE_ruleName} field) or -1 if not found.//
 func (p *BaseParser) GetRuleIndex(ruleName string) int {
 	var ruleIndex, ok = p.GetRuleIndexMap()[ruleName]
@@ -21,13 +21,16 @@ var (
 type ParserATNSimulator struct {
 	*BaseATNSimulator
 
-	parser         Parser
-	predictionMode int
-	input          TokenStream
-	startIndex     int
-	dfa            *DFA
-	mergeCache     *DoubleDict
-	outerContext   ParserRuleContext
+	parser             Parser
+	predictionMode     int
+	input              TokenStream
+	startIndex         int
+	dfa                *DFA
+	mergeCache         *DoubleDict
+	outerContext       ParserRuleContext
+	profiler           *ProfilingATNSimulator
+	cacheObserver      func(decision int, hit bool)
+	predictionObserver func(decision, alt int)
 }
 
 func NewParserATNSimulator(parser Parser, atn *ATN, decisionToDFA []*DFA, sharedContextCache *PredictionContextCache) *ParserATNSimulator {
@@ -58,6 +61,12 @@ func NewParserATNSimulator(parser Parser, atn *ATN, decisionToDFA []*DFA, shared
 	return p
 }
 
+// SetParser sets the parser whose semantic predicates, precedence and error
+// listeners are used during prediction.
+func (p *ParserATNSimulator) SetParser(parser Parser) {
+	p.parser = parser
+}
+
 func (p *ParserATNSimulator) GetPredictionMode() int {
 	return p.predictionMode
 }
@@ -66,10 +75,44 @@ func (p *ParserATNSimulator) SetPredictionMode(v int) {
 	p.predictionMode = v
 }
 
+// SetCacheObserver registers observer to be called on each DFA cache lookup
+// made during prediction: once for the start state of the decision and once
+// per lookahead token, with hit reporting whether the DFA already had the
+// state or edge. A nil observer, the default, turns the callback off.
+func (p *ParserATNSimulator) SetCacheObserver(observer func(decision int, hit bool)) {
+	p.cacheObserver = observer
+}
+
+// SetPredictionObserver registers observer to be called with the alternative
+// predicted by each AdaptivePredict call. A nil observer, the default, turns
+// the callback off.
+func (p *ParserATNSimulator) SetPredictionObserver(observer func(decision, alt int)) {
+	p.predictionObserver = observer
+}
+
+// copySettings sets the prediction mode and observers of p to those of
+// from, for a simulator replacing from.
+func (p *ParserATNSimulator) copySettings(from *ParserATNSimulator) {
+	p.predictionMode = from.predictionMode
+	p.cacheObserver = from.cacheObserver
+	p.predictionObserver = from.predictionObserver
+}
+
 func (p *ParserATNSimulator) reset() {
 }
 
 func (p *ParserATNSimulator) AdaptivePredict(input TokenStream, decision int, outerContext ParserRuleContext) int {
+	if p.profiler != nil {
+		return p.profiler.AdaptivePredict(input, decision, outerContext)
+	}
+	alt := p.adaptivePredict(input, decision, outerContext)
+	if p.predictionObserver != nil {
+		p.predictionObserver(decision, alt)
+	}
+	return alt
+}
+
+func (p *ParserATNSimulator) adaptivePredict(input TokenStream, decision int, outerContext ParserRuleContext) int {
 	if ParserATNSimulatorDebug || ParserATNSimulatorListATNDecisions {
 		fmt.Println("AdaptivePredict decision " + strconv.Itoa(decision) +
 			" exec LA(1)==" + p.getLookaheadName(input) +
@@ -105,6 +148,10 @@ func (p *ParserATNSimulator) AdaptivePredict(input TokenStream, decision int, ou
 		s0 = dfa.getS0()
 	}
 
+	if p.cacheObserver != nil {
+		p.cacheObserver(decision, s0 != nil)
+	}
+
 	if s0 == nil {
 		if outerContext == nil {
 			outerContext = RuleContextEmpty
@@ -199,6 +246,9 @@ func (p *ParserATNSimulator) execATN(dfa *DFA, s0 *DFAState, input TokenStream,
 	t := input.LA(1)
 	for { // for more work
 		D := p.getExistingTargetState(previousD, t)
+		if p.cacheObserver != nil {
+			p.cacheObserver(dfa.decision, D != nil)
+		}
 		if D == nil {
 			D = p.computeTargetState(dfa, previousD, t)
 		}
@@ -295,12 +345,20 @@ func (p *ParserATNSimulator) execATN(dfa *DFA, s0 *DFAState, input TokenStream,
 // already cached
 
 func (p *ParserATNSimulator) getExistingTargetState(previousD *DFAState, t int) *DFAState {
+	if p.profiler != nil {
+		p.profiler.sllStopIndex = p.input.Index()
+	}
+
 	edges := previousD.getEdges()
 	if edges == nil || t+1 < 0 || t+1 >= len(edges) {
 		return nil
 	}
 
-	return previousD.getIthEdge(t + 1)
+	existing := previousD.getIthEdge(t + 1)
+	if p.profiler != nil && existing != nil {
+		p.profiler.dfaTransition(existing)
+	}
+	return existing
 }
 
 // Compute a target state for an edge in the DFA, and attempt to add the
@@ -490,6 +548,13 @@ func (p *ParserATNSimulator) execATNWithFullContext(dfa *DFA, D *DFAState, s0 AT
 }
 
 func (p *ParserATNSimulator) computeReachSet(closure ATNConfigSet, t int, fullCtx bool) ATNConfigSet {
+	if p.profiler != nil {
+		return p.profiler.computeReachSet(closure, t, fullCtx)
+	}
+	return p.computeReachSetNoProfile(closure, t, fullCtx)
+}
+
+func (p *ParserATNSimulator) computeReachSetNoProfile(closure ATNConfigSet, t int, fullCtx bool) ATNConfigSet {
 	if ParserATNSimulatorDebug {
 		fmt.Println("in computeReachSet, starting closure: " + closure.String())
 	}
@@ -954,6 +1019,9 @@ func (p *ParserATNSimulator) evalSemanticContext(predPredictions []*PredPredicti
 		}
 
 		predicateEvaluationResult := pair.pred.evaluate(p.parser, outerContext)
+		if p.profiler != nil {
+			p.profiler.predicateEvaluated(pair.pred)
+		}
 		if ParserATNSimulatorDebug || ParserATNSimulatorDFADebug {
 			fmt.Println("eval pred " + pair.String() + "=" + fmt.Sprint(predicateEvaluationResult))
 		}
@@ -1508,6 +1576,9 @@ func (p *ParserATNSimulator) ReportAttemptingFullContext(dfa *DFA, conflictingAl
 		fmt.Println("ReportAttemptingFullContext decision=" + strconv.Itoa(dfa.decision) + ":" + configs.String() +
 			", input=" + p.parser.GetTokenStream().GetTextFromInterval(interval))
 	}
+	if p.profiler != nil {
+		p.profiler.attemptingFullContext(conflictingAlts, configs)
+	}
 	if p.parser != nil {
 		p.parser.GetErrorListenerDispatch().ReportAttemptingFullContext(p.parser, dfa, startIndex, stopIndex, conflictingAlts, configs)
 	}
@@ -1519,6 +1590,9 @@ func (p *ParserATNSimulator) ReportContextSensitivity(dfa *DFA, prediction int,
 		fmt.Println("ReportContextSensitivity decision=" + strconv.Itoa(dfa.decision) + ":" + configs.String() +
 			", input=" + p.parser.GetTokenStream().GetTextFromInterval(interval))
 	}
+	if p.profiler != nil {
+		p.profiler.contextSensitivity(prediction)
+	}
 	if p.parser != nil {
 		p.parser.GetErrorListenerDispatch().ReportContextSensitivity(p.parser, dfa, startIndex, stopIndex, prediction, configs)
 	}
@@ -1532,6 +1606,9 @@ func (p *ParserATNSimulator) ReportAmbiguity(dfa *DFA, D *DFAState, startIndex,
 		fmt.Println("ReportAmbiguity " + ambigAlts.String() + ":" + configs.String() +
 			", input=" + p.parser.GetTokenStream().GetTextFromInterval(interval))
 	}
+	if p.profiler != nil {
+		p.profiler.ambiguity(ambigAlts, configs)
+	}
 	if p.parser != nil {
 		p.parser.GetErrorListenerDispatch().ReportAmbiguity(p.parser, dfa, startIndex, stopIndex, exact, ambigAlts, configs)
 	}
@@ -0,0 +1,54 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+type parserATNSimulatorTestCacheStats struct {
+	hits   map[int]int
+	misses map[int]int
+}
+
+func newParserATNSimulatorTestCacheStats() *parserATNSimulatorTestCacheStats {
+	return &parserATNSimulatorTestCacheStats{hits: make(map[int]int), misses: make(map[int]int)}
+}
+
+func (s *parserATNSimulatorTestCacheStats) observe(decision int, hit bool) {
+	if hit {
+		s.hits[decision]++
+	} else {
+		s.misses[decision]++
+	}
+}
+
+func TestParserATNSimulatorCacheObserver(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(x) { x = 1; y; }"
+
+	stats := newParserATNSimulatorTestCacheStats()
+	p := newExprParserFor(input)
+	p.GetInterpreter().SetCacheObserver(stats.observe)
+	p.Prog()
+
+	// 'x = 1;' misses the start state and both edges, 'y;' then hits the
+	// start state and the ID edge but misses the ';' edge.
+	assert.Equal(4, stats.misses[3])
+	assert.Equal(2, stats.hits[3])
+
+	second := newParserATNSimulatorTestCacheStats()
+	p.GetInterpreter().SetCacheObserver(second.observe)
+	p.Reset(newExprTokenStream(input))
+	p.Prog()
+
+	assert.Equal(0, len(second.misses))
+	assert.Equal(6, second.hits[3])
+
+	p.GetInterpreter().SetCacheObserver(nil)
+	p.Reset(newExprTokenStream(input))
+	p.Prog()
+	assert.Equal(6, second.hits[3])
+}
@@ -28,6 +28,8 @@ type ParserRuleContext interface {
 
 	AddChild(child RuleContext) RuleContext
 	RemoveLastChild()
+
+	PreviousToken(stream *CommonTokenStream, includeHidden bool) Token
 }
 
 type BaseParserRuleContext struct {
@@ -72,6 +74,7 @@ func (prc *BaseParserRuleContext) CopyFrom(ctx *BaseParserRuleContext) {
 	// from RuleContext
 	prc.parentCtx = ctx.parentCtx
 	prc.invokingState = ctx.invokingState
+	prc.outerAlt = ctx.outerAlt
 	prc.children = nil
 	prc.start = ctx.start
 	prc.stop = ctx.stop
@@ -193,6 +196,27 @@ func (prc *BaseParserRuleContext) GetStart() Token {
 	return prc.start
 }
 
+// PreviousToken returns the token of stream right before the start token of
+// prc. Unless includeHidden is true, tokens that are not on the channel of
+// stream are skipped. It returns nil if there is no such token, in
+// particular if prc starts at the first token.
+func (prc *BaseParserRuleContext) PreviousToken(stream *CommonTokenStream, includeHidden bool) Token {
+	if prc.start == nil {
+		return nil
+	}
+	i := prc.start.GetTokenIndex() - 1
+	if i >= stream.Size() {
+		return nil
+	}
+	if !includeHidden {
+		i = stream.previousTokenOnChannel(i, stream.channel)
+	}
+	if i < 0 {
+		return nil
+	}
+	return stream.Get(i)
+}
+
 func (prc *BaseParserRuleContext) SetStop(t Token) {
 	prc.stop = t
 }
@@ -201,6 +225,8 @@ func (prc *BaseParserRuleContext) GetStop() Token {
 	return prc.stop
 }
 
+// GetToken returns the i-th terminal child, counting from 0, whose symbol is
+// of type ttype, or nil if there are not that many.
 func (prc *BaseParserRuleContext) GetToken(ttype int, i int) TerminalNode {
 
 	for j := 0; j < len(prc.children); j++ {
@@ -218,6 +244,8 @@ func (prc *BaseParserRuleContext) GetToken(ttype int, i int) TerminalNode {
 	return nil
 }
 
+// GetTokens returns, in order, the terminal children whose symbol is of type
+// ttype. Terminals in nested rule contexts are not included.
 func (prc *BaseParserRuleContext) GetTokens(ttype int) []TerminalNode {
 	if prc.children == nil {
 		return make([]TerminalNode, 0)
@@ -340,6 +368,32 @@ func (prc *BaseParserRuleContext) String(ruleNames []string, stop RuleContext) s
 	return s
 }
 
+// GetTextWithHidden returns the source text of ctx as it appears in the input
+// of stream, from the first character of its start token to the last one of
+// its stop token. Unlike ctx.GetText it keeps everything in between, such as
+// hidden channel tokens and input the lexer skipped. If the last child of ctx
+// is EOF, the text extends to the end of the input. It returns "" for a
+// context that matched no tokens.
+func GetTextWithHidden(ctx ParserRuleContext, stream *CommonTokenStream) string {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+	if n := ctx.GetChildCount(); n > 0 {
+		if last, ok := ctx.GetChild(n - 1).(TerminalNode); ok && last.GetSymbol().GetTokenType() == TokenEOF {
+			stop = last.GetSymbol()
+		}
+	}
+	if start == nil || stop == nil || start.GetTokenType() == TokenEOF {
+		return ""
+	}
+	stopChar := stop.GetStop()
+	if stop.GetTokenType() == TokenEOF {
+		stopChar = stop.GetStart() - 1
+	}
+	if stopChar < start.GetStart() {
+		return ""
+	}
+	return stream.GetTokenSource().GetInputStream().GetText(start.GetStart(), stopChar)
+}
+
 var RuleContextEmpty = NewBaseParserRuleContext(nil, -1)
 
 type InterpreterRuleContext interface {
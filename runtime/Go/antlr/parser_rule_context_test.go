@@ -0,0 +1,140 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParserRuleContextPreviousToken(t *testing.T) {
+	assert := assertNew(t)
+	input := "// first\ndef f(x) { x; }\n// second\ndef g(y) { return y; }"
+	stream := NewCommonTokenStream(newExprCommentLexer(input), TokenDefaultChannel)
+	tree := NewExprParser(stream).Prog()
+	funcs := TreesfindAllRuleNodes(tree, ExprParserRULE_func_)
+
+	g := funcs[1].(ParserRuleContext)
+	assert.Equal("// second", g.PreviousToken(stream, true).GetText())
+	assert.Equal("}", g.PreviousToken(stream, false).GetText())
+
+	f := funcs[0].(ParserRuleContext)
+	assert.Equal("// first", f.PreviousToken(stream, true).GetText())
+	assert.Nil(f.PreviousToken(stream, false))
+
+	ret := TreesfindAllRuleNodes(tree, ExprParserRULE_stat)[0].(ParserRuleContext)
+	assert.Equal("{", ret.PreviousToken(stream, false).GetText())
+
+	assert.Nil(tree.PreviousToken(stream, false))
+	assert.Nil(NewBaseParserRuleContext(nil, -1).PreviousToken(stream, true))
+}
+
+func TestParserRuleContextGetTokens(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(a, b, c) { return a; }").Prog()
+	f := tree.GetChild(0).(*Func_Context)
+
+	commas := f.GetTokens(ExprParserT__2)
+	assert.Equal(2, len(commas))
+	assert.Equal(",", commas[0].GetText())
+	assert.Equal(6, commas[1].GetSymbol().GetTokenIndex())
+	assert.Equal("f", f.GetToken(ExprParserID, 0).GetText())
+	assert.Equal(commas[1], f.GetToken(ExprParserT__2, 1))
+
+	// the IDs of the arguments and the body are below other rule contexts
+	assert.Equal(1, len(f.GetTokens(ExprParserID)))
+	assert.Nil(f.GetToken(ExprParserID, 1))
+	assert.Nil(f.GetToken(ExprParserT__2, 2))
+	assert.Nil(f.GetToken(ExprParserT__2, -1))
+	assert.Nil(f.GetToken(ExprParserRETURN, 0))
+	assert.Equal(0, len(f.GetTokens(ExprParserRETURN)))
+	assert.Equal(0, len(NewBaseParserRuleContext(nil, -1).GetTokens(ExprParserID)))
+}
+
+func TestGetTextWithHidden(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(x) {\n  x = x  +  1; // bump\n  return x;\n}\n// trailing\n"
+	stream := NewCommonTokenStream(newExprCommentLexer(input), TokenDefaultChannel)
+	p := NewExprParser(stream)
+	p.SetAttachEOF(true)
+	tree := p.Prog()
+
+	f := TreesfindAllRuleNodes(tree, ExprParserRULE_func_)[0].(ParserRuleContext)
+	assert.Equal(input[:strings.Index(input, "}")+1], GetTextWithHidden(f, stream))
+
+	stat := TreesfindAllRuleNodes(tree, ExprParserRULE_stat)[0].(ParserRuleContext)
+	assert.Equal("x = x  +  1;", GetTextWithHidden(stat, stream))
+	assert.Equal("x=x+1;", stat.GetText())
+
+	// a single token
+	primary := TreesfindAllRuleNodes(tree, ExprParserRULE_primary)[0].(ParserRuleContext)
+	assert.Equal("x", GetTextWithHidden(primary, stream))
+
+	// the last child of the tree is EOF
+	assert.Equal(input, GetTextWithHidden(tree, stream))
+
+	assert.Equal("", GetTextWithHidden(NewBaseParserRuleContext(nil, -1), stream))
+}
+
+func TestParserRuleContextGetOuterAltNumber(t *testing.T) {
+	assert := assertNew(t)
+	for input, alt := range map[string]int{"x;": 1, "x = 1;": 2, "return x;": 3, ";": 4} {
+		stat := newExprParserFor(input).Stat()
+		assert.Equal(alt, stat.GetOuterAltNumber())
+		// the default GetAltNumber is left alone
+		assert.Equal(ATNInvalidAltNumber, stat.GetAltNumber())
+	}
+
+	// primary : INT | ID ;
+	p := newExprParserFor("x + 1")
+	expr := p.Expr()
+	primaries := TreesfindAllRuleNodes(expr, ExprParserRULE_primary)
+	assert.Equal(2, primaries[0].(ParserRuleContext).GetOuterAltNumber())
+	assert.Equal(1, primaries[1].(ParserRuleContext).GetOuterAltNumber())
+	// the context for the operator alternative wraps the left operand
+	assert.Equal(ATNInvalidAltNumber, expr.GetOuterAltNumber())
+	assert.Equal("(expr (expr (primary x)) + (expr (primary 1)))", expr.ToStringTree(nil, p))
+
+	assert.Equal(ATNInvalidAltNumber, NewBaseParserRuleContext(nil, -1).GetOuterAltNumber())
+}
+
+// parserRuleContextTestAltNumContext stores its alternative number like the
+// contexts of a grammar with contextSuperClass=RuleContextWithAltNum.
+type parserRuleContextTestAltNumContext struct {
+	*BaseParserRuleContext
+
+	altNum int
+}
+
+func (c *parserRuleContextTestAltNumContext) GetAltNumber() int {
+	return c.altNum
+}
+
+func (c *parserRuleContextTestAltNumContext) SetAltNumber(altNumber int) {
+	c.altNum = altNumber
+}
+
+func (c *parserRuleContextTestAltNumContext) GetRuleContext() RuleContext {
+	return c
+}
+
+func (c *parserRuleContextTestAltNumContext) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(c, ruleNames, recog)
+}
+
+func TestParserRuleContextAltNumberOverride(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x;")
+	ctx := &parserRuleContextTestAltNumContext{BaseParserRuleContext: NewBaseParserRuleContext(nil, -1)}
+	ctx.RuleIndex = ExprParserRULE_stat
+	p.EnterRule(ctx, 0, ExprParserRULE_stat)
+	p.EnterOuterAlt(ctx, 3)
+	p.Match(ExprParserID)
+	p.ExitRule()
+
+	assert.Equal(3, ctx.GetAltNumber())
+	assert.Equal(3, ctx.GetOuterAltNumber())
+	assert.Equal("(stat:3 x)", ctx.ToStringTree(exprParser_ruleNames, nil))
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"fmt"
+	"testing"
+)
+
+type parserTestErrorListener struct {
+	*DefaultErrorListener
+
+	messages []string
+}
+
+func (l *parserTestErrorListener) SyntaxError(recognizer Recognizer, offendingSymbol interface{}, line, column int, msg string, e RecognitionException) {
+	l.messages = append(l.messages, msg)
+}
+
+func newExprTokenStream(input string) TokenStream {
+	return NewCommonTokenStream(NewExprLexer(NewInputStream(input)), TokenDefaultChannel)
+}
+
+var parserTestInputs = []string{
+	"def f(x) { x = 1+2*3; return x; }",
+	"def g(a, b) { return (a-b)/2; ; }\ndef h(c) { c; }",
+}
+
+func TestParserResetProducesIdenticalTrees(t *testing.T) {
+	assert := assertNew(t)
+
+	reused := NewExprParser(newExprTokenStream(parserTestInputs[0]))
+	reused.Prog()
+
+	for _, input := range parserTestInputs {
+		fresh := NewExprParser(newExprTokenStream(input))
+		expected := fresh.Prog().ToStringTree(nil, fresh)
+
+		reused.Reset(newExprTokenStream(input))
+		assert.Nil(reused.GetParserRuleContext())
+		assert.Equal(expected, reused.Prog().ToStringTree(nil, reused))
+	}
+}
+
+func TestParserResetClearsSyntaxErrors(t *testing.T) {
+	assert := assertNew(t)
+
+	listener := &parserTestErrorListener{DefaultErrorListener: NewDefaultErrorListener()}
+	p := NewExprParser(newExprTokenStream("def f(x) { x = ; }"))
+	p.RemoveErrorListeners()
+	p.AddErrorListener(listener)
+
+	p.Prog()
+	assert.Equal(1, p.GetNumberOfSyntaxErrors())
+	assert.Equal(1, len(listener.messages))
+
+	p.SetTrace(NewTraceListener(p.BaseParser))
+
+	p.Reset(newExprTokenStream("def f(x) { x = ; y = ; }"))
+	assert.Equal(0, p.GetNumberOfSyntaxErrors())
+	assert.Equal(0, len(p.GetParseListeners()))
+
+	// The listener stays attached across the reset.
+	p.Prog()
+	assert.Equal(2, p.GetNumberOfSyntaxErrors())
+	assert.Equal(3, len(listener.messages))
+}
+
+func TestParserResetKeepsDFA(t *testing.T) {
+	assert := assertNew(t)
+
+	p := NewExprParser(newExprTokenStream(parserTestInputs[0]))
+	interpreter := p.GetInterpreter()
+	p.Prog()
+	states := len(interpreter.DecisionToDFA()[3].states)
+
+	p.Reset(newExprTokenStream(parserTestInputs[0]))
+	assert.Equal(interpreter, p.GetInterpreter())
+	assert.Equal(states, len(p.GetInterpreter().DecisionToDFA()[3].states))
+}
+
+func BenchmarkParserReset(b *testing.B) {
+	p := NewExprParser(newExprTokenStream(parserTestInputs[1]))
+	for i := 0; i < b.N; i++ {
+		p.Reset(newExprTokenStream(parserTestInputs[1]))
+		p.Prog()
+	}
+}
+
+func BenchmarkParserFresh(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p := NewExprParser(newExprTokenStream(parserTestInputs[1]))
+		p.Prog()
+	}
+}
+
+func newExprParserWithFreshState(p *ExprParser, input string) *ExprParser {
+	q := &ExprParser{BaseParser: p.WithFreshState()}
+	q.Interpreter.SetParser(q)
+	q.SetTokenStream(newExprTokenStream(input))
+	return q
+}
+
+func TestParserWithFreshState(t *testing.T) {
+	assert := assertNew(t)
+
+	p := NewExprParser(newExprTokenStream("def f(x) { x = ; }"))
+	p.RemoveErrorListeners()
+	p.Prog()
+	assert.Equal(1, p.GetNumberOfSyntaxErrors())
+
+	q := newExprParserWithFreshState(p, parserTestInputs[0])
+	assert.Equal(0, q.GetNumberOfSyntaxErrors())
+	assert.Equal(0, len(q.listeners))
+	assert.Equal(true, p.Interpreter.atn == q.Interpreter.atn)
+	assert.Equal(true, p.Interpreter.decisionToDFA[3] == q.Interpreter.decisionToDFA[3])
+	assert.Equal(false, p.Interpreter.sharedContextCache == q.Interpreter.sharedContextCache)
+
+	fresh := NewExprParser(newExprTokenStream(parserTestInputs[0]))
+	assert.Equal(fresh.Prog().ToStringTree(nil, fresh), q.Prog().ToStringTree(nil, q))
+	assert.Equal(1, p.GetNumberOfSyntaxErrors())
+}
+
+func TestParserWithFreshStateConcurrent(t *testing.T) {
+	assert := assertNew(t)
+
+	p := NewExprParser(nil)
+	expected := make([]string, len(parserTestInputs))
+	for i, input := range parserTestInputs {
+		fresh := NewExprParser(newExprTokenStream(input))
+		expected[i] = fresh.Prog().ToStringTree(nil, fresh)
+	}
+
+	const goroutines = 8
+	results := make(chan []string, goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			trees := make([]string, 0, len(parserTestInputs))
+			for _, input := range parserTestInputs {
+				q := newExprParserWithFreshState(p, input)
+				trees = append(trees, q.Prog().ToStringTree(nil, q))
+			}
+			results <- trees
+		}()
+	}
+	for g := 0; g < goroutines; g++ {
+		assert.Equal(expected, <-results)
+	}
+	assert.Equal(true, p.Interpreter.decisionToDFA[3].numStates() > 0)
+}
+
+type parserTestTerminalListener struct {
+	*BaseParseTreeListener
+
+	types []int
+}
+
+func (l *parserTestTerminalListener) VisitTerminal(node TerminalNode) {
+	l.types = append(l.types, node.GetSymbol().GetTokenType())
+}
+
+func TestParserSetAttachEOF(t *testing.T) {
+	assert := assertNew(t)
+
+	p := NewExprParser(newExprTokenStream(parserTestInputs[1]))
+	p.SetAttachEOF(true)
+	l := &parserTestTerminalListener{BaseParseTreeListener: &BaseParseTreeListener{}}
+	p.AddParseListener(l)
+	tree := p.Prog()
+	assert.Equal(3, tree.GetChildCount())
+	eof, ok := tree.GetChild(2).(TerminalNode)
+	assert.Equal(true, ok)
+	assert.Equal(TokenEOF, eof.GetSymbol().GetTokenType())
+	assert.Equal(treesNodeKey(tree), treesNodeKey(eof.GetParent()))
+	assert.Equal("}", tree.GetStop().GetText())
+	assert.Equal(1, len(TreesFindAllTokenNodes(tree, TokenEOF)))
+	assert.Equal(TokenEOF, l.types[len(l.types)-1])
+
+	// a walk reports it like any other terminal
+	walked := &parserTestTerminalListener{BaseParseTreeListener: &BaseParseTreeListener{}}
+	ParseTreeWalkerDefault.Walk(walked, tree)
+	assert.Equal(l.types, walked.types)
+
+	// the start rule may be left recursive
+	p = NewExprParser(newExprTokenStream("1+2*3"))
+	p.SetAttachEOF(true)
+	expr := p.Expr()
+	assert.Equal(TokenEOF, expr.GetChild(expr.GetChildCount()-1).(TerminalNode).GetSymbol().GetTokenType())
+
+	// nothing is attached when the rule stops early or by default
+	p = NewExprParser(newExprTokenStream("def f(x) { x; } 1"))
+	p.RemoveErrorListeners()
+	p.SetAttachEOF(true)
+	assert.Equal(0, len(TreesFindAllTokenNodes(p.Prog(), TokenEOF)))
+	p = NewExprParser(newExprTokenStream(parserTestInputs[1]))
+	assert.Equal(0, len(TreesFindAllTokenNodes(p.Prog(), TokenEOF)))
+}
+
+func TestParserSetPredicateObserver(t *testing.T) {
+	assert := assertNew(t)
+	for _, allow := range []bool{true, false} {
+		p := newPredParserFor("x", allow)
+		var outcomes []string
+		p.SetPredicateObserver(func(ruleIndex, predIndex int, result bool) {
+			outcomes = append(outcomes, fmt.Sprint(ruleIndex, predIndex, result))
+		})
+		p.S()
+		assert.Equal(0, p.GetNumberOfSyntaxErrors())
+		// prediction may evaluate the predicate more than once
+		assert.Equal(true, len(outcomes) > 0)
+		for _, outcome := range outcomes {
+			assert.Equal(fmt.Sprint(0, 0, allow), outcome)
+		}
+	}
+
+	// no predicate is evaluated when the lookahead decides
+	p := NewExprParser(NewCommonTokenStream(NewExprLexer(NewInputStream("x = 1;")), TokenDefaultChannel))
+	called := false
+	p.SetPredicateObserver(func(ruleIndex, predIndex int, result bool) { called = true })
+	p.Stat()
+	assert.Equal(false, called)
+}
+
+// parserTestExpectedRulesListener records the expected rules at the first
+// syntax error.
+type parserTestExpectedRulesListener struct {
+	*DefaultErrorListener
+
+	rules []int
+}
+
+func (l *parserTestExpectedRulesListener) SyntaxError(recognizer Recognizer, offendingSymbol interface{}, line, column int, msg string, e RecognitionException) {
+	if l.rules == nil {
+		l.rules = recognizer.(*BaseParser).ExpectedRulesAtPosition()
+	}
+}
+
+func TestParserExpectedRulesAtPosition(t *testing.T) {
+	assert := assertNew(t)
+	expectedRulesAtError := func(input string) []int {
+		p := newExprParserFor(input)
+		p.RemoveErrorListeners()
+		l := &parserTestExpectedRulesListener{DefaultErrorListener: NewDefaultErrorListener()}
+		p.AddErrorListener(l)
+		p.Prog()
+		return l.rules
+	}
+
+	// after the statement keyword an expression begins
+	assert.Equal([]int{ExprParserRULE_expr, ExprParserRULE_primary}, expectedRulesAtError("def f(x) { return }"))
+	// after a statement, another one or the end of the body
+	assert.Equal([]int{ExprParserRULE_stat, ExprParserRULE_expr, ExprParserRULE_primary}, expectedRulesAtError("def f(x) { x; ) }"))
+	// an argument list
+	assert.Equal([]int{ExprParserRULE_arg}, expectedRulesAtError("def f(1) { x; }"))
+}
+
+func TestParserExpectedRulesAtPositionOutsideParse(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x;")
+	assert.Equal([]int{}, p.ExpectedRulesAtPosition())
+
+	p.Stat()
+	p.Reset(newExprTokenStream("y;"))
+	assert.Equal([]int{}, p.ExpectedRulesAtPosition())
+
+	assert.Equal([]int{}, p.WithFreshState().ExpectedRulesAtPosition())
+	assert.Equal([]int{}, NewBaseParser(nil).ExpectedRulesAtPosition())
+}
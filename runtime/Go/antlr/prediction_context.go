@@ -5,7 +5,9 @@
 package antlr
 
 import (
+	"container/list"
 	"strconv"
+	"sync"
 )
 
 // Represents {@code $} in local context prediction, which means wildcard.
@@ -73,13 +75,24 @@ func calculateEmptyHash() int {
 // context cash associated with contexts in DFA states. This cache
 // can be used for both lexers and parsers.
 
+// PredictionContextCache canonicalizes the prediction contexts stored in
+// DFA states so that equal graphs are shared. By default it grows without
+// bound; SetMaxSize limits it to a number of entries, evicting the least
+// recently used ones. Evicting a context only means it is no longer shared:
+// DFA states and predictions that hold it keep using it unchanged.
 type PredictionContextCache struct {
-	cache map[PredictionContext]PredictionContext
+	mu      sync.Mutex
+	cache   map[PredictionContext]*list.Element
+	lru     *list.List // most recently used first
+	maxSize int
+
+	hits, misses, evictions int
 }
 
 func NewPredictionContextCache() *PredictionContextCache {
 	t := new(PredictionContextCache)
-	t.cache = make(map[PredictionContext]PredictionContext)
+	t.cache = make(map[PredictionContext]*list.Element)
+	t.lru = list.New()
 	return t
 }
 
@@ -91,22 +104,77 @@ func (p *PredictionContextCache) add(ctx PredictionContext) PredictionContext {
 	if ctx == BasePredictionContextEMPTY {
 		return BasePredictionContextEMPTY
 	}
-	existing := p.cache[ctx]
-	if existing != nil {
-		return existing
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e := p.cache[ctx]; e != nil {
+		p.hits++
+		p.lru.MoveToFront(e)
+		return e.Value.(PredictionContext)
 	}
-	p.cache[ctx] = ctx
+	p.misses++
+	p.cache[ctx] = p.lru.PushFront(ctx)
+	p.evict()
 	return ctx
 }
 
 func (p *PredictionContextCache) Get(ctx PredictionContext) PredictionContext {
-	return p.cache[ctx]
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e := p.cache[ctx]
+	if e == nil {
+		p.misses++
+		return nil
+	}
+	p.hits++
+	p.lru.MoveToFront(e)
+	return e.Value.(PredictionContext)
 }
 
 func (p *PredictionContextCache) length() int {
+	return p.Len()
+}
+
+// Len returns the number of contexts in the cache.
+func (p *PredictionContextCache) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return len(p.cache)
 }
 
+// SetMaxSize limits the cache to n contexts, evicting the least recently
+// used ones right away if it holds more. A size of 0 or less, the default,
+// removes the limit.
+func (p *PredictionContextCache) SetMaxSize(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maxSize = n
+	p.evict()
+}
+
+// Stats returns the number of lookups that found a cached context, the
+// number that did not, and the number of contexts evicted so far. Adding a
+// context counts as a hit if it is already cached and as a miss if it is
+// inserted.
+func (p *PredictionContextCache) Stats() (hits, misses, evictions int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.hits, p.misses, p.evictions
+}
+
+// evict removes least recently used contexts until the cache fits maxSize.
+// p.mu must be held.
+func (p *PredictionContextCache) evict() {
+	if p.maxSize <= 0 {
+		return
+	}
+	for len(p.cache) > p.maxSize {
+		e := p.lru.Back()
+		p.lru.Remove(e)
+		delete(p.cache, e.Value.(PredictionContext))
+		p.evictions++
+	}
+}
+
 type SingletonPredictionContext interface {
 	PredictionContext
 }
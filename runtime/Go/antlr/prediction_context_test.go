@@ -0,0 +1,65 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestPredictionContextCacheEviction(t *testing.T) {
+	assert := assertNew(t)
+	cache := NewPredictionContextCache()
+	a := SingletonBasePredictionContextCreate(BasePredictionContextEMPTY, 1)
+	b := SingletonBasePredictionContextCreate(BasePredictionContextEMPTY, 2)
+	c := SingletonBasePredictionContextCreate(BasePredictionContextEMPTY, 3)
+
+	cache.add(a)
+	cache.add(b)
+	cache.add(c)
+	assert.Equal(3, cache.Len())
+
+	// a is used most recently, so b is the least recently used
+	assert.Equal(a, cache.Get(a))
+	cache.SetMaxSize(2)
+	assert.Equal(2, cache.Len())
+	assert.Nil(cache.Get(b))
+
+	d := SingletonBasePredictionContextCreate(BasePredictionContextEMPTY, 4)
+	cache.add(d)
+	assert.Equal(2, cache.Len())
+	assert.Nil(cache.Get(c))
+	assert.Equal(a, cache.Get(a))
+	assert.Equal(d, cache.Get(d))
+	assert.Equal(a, cache.add(a))
+
+	// a, b, c and d were inserted, b and c looked up after their eviction
+	hits, misses, evictions := cache.Stats()
+	assert.Equal(4, hits)
+	assert.Equal(6, misses)
+	assert.Equal(2, evictions)
+
+	cache.SetMaxSize(0)
+	cache.add(b)
+	cache.add(c)
+	assert.Equal(4, cache.Len())
+}
+
+func TestPredictionContextCacheMaxSizeParse(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(x) { x = (1+2)*3; y; return (x); }"
+
+	fresh := NewExprParser(newExprTokenStream(input))
+	expected := fresh.Prog().ToStringTree(nil, fresh)
+	assert.Equal(true, fresh.Interpreter.SharedContextCache().Len() > 1)
+
+	p := NewExprParser(newExprTokenStream(input))
+	cache := p.Interpreter.SharedContextCache()
+	cache.SetMaxSize(1)
+	assert.Equal(expected, p.Prog().ToStringTree(nil, p))
+	assert.Equal(1, cache.Len())
+
+	_, _, evictions := cache.Stats()
+	assert.Equal(true, evictions > 0)
+}
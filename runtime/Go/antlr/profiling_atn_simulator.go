@@ -0,0 +1,163 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"time"
+)
+
+// ProfilingATNSimulator is a ParserATNSimulator that records a DecisionInfo
+// for every decision of the ATN while it predicts. It is installed by
+// Parser.SetProfile and read through Parser.GetParseInfo.
+type ProfilingATNSimulator struct {
+	*ParserATNSimulator
+
+	decisions []*DecisionInfo
+
+	sllStopIndex    int
+	llStopIndex     int
+	currentDecision int
+
+	// The alternative chosen by SLL prediction when it fell back to full
+	// context, used to tell context sensitivities from plain conflicts.
+	conflictingAltResolvedBySLL int
+}
+
+// NewProfilingATNSimulator creates a profiling simulator for parser that
+// shares the ATN, DFA cache and prediction context cache of its current
+// interpreter, and its prediction mode and observers.
+func NewProfilingATNSimulator(parser Parser) *ProfilingATNSimulator {
+	interp := parser.GetInterpreter()
+
+	p := new(ProfilingATNSimulator)
+	p.ParserATNSimulator = NewParserATNSimulator(interp.parser, interp.atn, interp.decisionToDFA, interp.sharedContextCache)
+	p.profiler = p
+	p.copySettings(interp)
+	p.currentDecision = -1
+
+	p.decisions = make([]*DecisionInfo, len(interp.atn.DecisionToState))
+	for i := range p.decisions {
+		p.decisions[i] = NewDecisionInfo(i)
+	}
+
+	return p
+}
+
+func (p *ProfilingATNSimulator) AdaptivePredict(input TokenStream, decision int, outerContext ParserRuleContext) int {
+	p.sllStopIndex = -1
+	p.llStopIndex = -1
+	p.currentDecision = decision
+	defer func() {
+		p.currentDecision = -1
+	}()
+
+	start := time.Now()
+	alt := p.adaptivePredict(input, decision, outerContext)
+	elapsed := time.Since(start)
+
+	info := p.decisions[decision]
+	info.TimeInPrediction += elapsed
+	info.Invocations++
+
+	sllK := p.sllStopIndex - p.startIndex + 1
+	info.SLLTotalLook += sllK
+	if info.Invocations == 1 || sllK < info.SLLMinLook {
+		info.SLLMinLook = sllK
+	}
+	if sllK > info.SLLMaxLook {
+		info.SLLMaxLook = sllK
+	}
+
+	if p.llStopIndex >= 0 {
+		llK := p.llStopIndex - p.startIndex + 1
+		info.LLTotalLook += llK
+		if info.LLMinLook == 0 || llK < info.LLMinLook {
+			info.LLMinLook = llK
+		}
+		if llK > info.LLMaxLook {
+			info.LLMaxLook = llK
+		}
+	}
+
+	if p.predictionObserver != nil {
+		p.predictionObserver(decision, alt)
+	}
+
+	return alt
+}
+
+func (p *ProfilingATNSimulator) computeReachSet(closure ATNConfigSet, t int, fullCtx bool) ATNConfigSet {
+	if fullCtx {
+		// this method is called after each time the input position advances
+		// during full context prediction
+		p.llStopIndex = p.input.Index()
+	}
+
+	reach := p.computeReachSetNoProfile(closure, t, fullCtx)
+
+	info := p.decisions[p.currentDecision]
+	if fullCtx {
+		info.LLATNTransitions++
+	} else {
+		info.SLLATNTransitions++
+	}
+	if reach == nil {
+		info.Errors++
+	}
+
+	return reach
+}
+
+func (p *ProfilingATNSimulator) dfaTransition(existing *DFAState) {
+	info := p.decisions[p.currentDecision]
+	info.SLLDFATransitions++
+	if existing == ATNSimulatorError {
+		info.Errors++
+	}
+}
+
+func (p *ProfilingATNSimulator) predicateEvaluated(pred SemanticContext) {
+	if _, ok := pred.(*PrecedencePredicate); ok {
+		return
+	}
+	p.decisions[p.currentDecision].PredicateEvals++
+}
+
+func (p *ProfilingATNSimulator) attemptingFullContext(conflictingAlts *BitSet, configs ATNConfigSet) {
+	if conflictingAlts != nil {
+		p.conflictingAltResolvedBySLL = conflictingAlts.minValue()
+	} else {
+		p.conflictingAltResolvedBySLL = configs.Alts().minValue()
+	}
+	p.decisions[p.currentDecision].LLFallback++
+}
+
+func (p *ProfilingATNSimulator) contextSensitivity(prediction int) {
+	if prediction != p.conflictingAltResolvedBySLL {
+		p.decisions[p.currentDecision].ContextSensitivities++
+	}
+}
+
+func (p *ProfilingATNSimulator) ambiguity(ambigAlts *BitSet, configs ATNConfigSet) {
+	var prediction int
+	if ambigAlts != nil {
+		prediction = ambigAlts.minValue()
+	} else {
+		prediction = configs.Alts().minValue()
+	}
+	if configs.FullContext() && prediction != p.conflictingAltResolvedBySLL {
+		// Even though this is an ambiguity we are still interested
+		// in the fact that full context prediction chose a different
+		// alternative than SLL would have.
+		p.decisions[p.currentDecision].ContextSensitivities++
+	}
+	p.decisions[p.currentDecision].Ambiguities++
+}
+
+// GetDecisionInfo returns the profiling data of every decision, indexed by
+// decision number.
+func (p *ProfilingATNSimulator) GetDecisionInfo() []*DecisionInfo {
+	return p.decisions
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestProfilingATNSimulatorDecisionInfo(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x = 1; return x; y; }")
+	p.SetProfile(true)
+	p.Prog()
+
+	info := p.GetParseInfo()
+	assert.NotNil(info)
+	decisions := info.GetDecisionInfo()
+	assert.Equal(len(p.GetATN().DecisionToState), len(decisions))
+
+	// stat needs two tokens to tell 'x =' and 'y ;' apart, one for 'return'.
+	stat := decisions[3]
+	assert.Equal(3, stat.Decision)
+	assert.Equal(3, stat.Invocations)
+	assert.Equal(5, stat.SLLTotalLook)
+	assert.Equal(1, stat.SLLMinLook)
+	assert.Equal(2, stat.SLLMaxLook)
+	assert.Equal(0, stat.LLFallback)
+	assert.Equal(0, stat.Ambiguities)
+	assert.Equal(0, stat.ContextSensitivities)
+	assert.Equal(0, len(info.GetLLDecisions()))
+	assert.Equal(5, stat.SLLATNTransitions+stat.SLLDFATransitions)
+	assert.Equal(true, info.GetDFASize() > 0)
+}
+
+func TestProfilingATNSimulatorOff(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x; }")
+	assert.Nil(p.GetParseInfo())
+
+	p.SetProfile(true)
+	p.SetProfile(true)
+	dfa := p.GetInterpreter().DecisionToDFA()
+	p.Prog()
+	assert.Equal(1, p.GetParseInfo().GetDecisionInfo()[3].Invocations)
+
+	p.SetProfile(false)
+	assert.Nil(p.GetParseInfo())
+	assert.Equal(dfa, p.GetInterpreter().DecisionToDFA())
+}
+
+func TestSetProfileKeepsInterpreterSettings(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x; y;")
+	var predictions, lookups int
+	interp := p.GetInterpreter()
+	interp.SetPredictionMode(PredictionModeSLL)
+	interp.SetPredictionObserver(func(decision, alt int) { predictions++ })
+	interp.SetCacheObserver(func(decision int, hit bool) { lookups++ })
+
+	p.SetProfile(true)
+	assert.Equal(PredictionModeSLL, p.GetInterpreter().GetPredictionMode())
+	p.Stat()
+	assert.Equal(true, predictions > 0)
+	assert.Equal(true, lookups > 0)
+
+	p.SetProfile(false)
+	assert.Equal(PredictionModeSLL, p.GetInterpreter().GetPredictionMode())
+	predictions, lookups = 0, 0
+	p.Stat()
+	assert.Equal(true, predictions > 0)
+	assert.Equal(true, lookups > 0)
+}
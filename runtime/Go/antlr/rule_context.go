@@ -36,6 +36,7 @@ type RuleContext interface {
 
 	GetAltNumber() int
 	SetAltNumber(altNumber int)
+	GetOuterAltNumber() int
 
 	String([]string, RuleContext) string
 }
@@ -44,6 +45,7 @@ type BaseRuleContext struct {
 	parentCtx     RuleContext
 	invokingState int
 	RuleIndex     int
+	outerAlt      int
 }
 
 func NewBaseRuleContext(parent RuleContext, invokingState int) *BaseRuleContext {
@@ -95,6 +97,20 @@ func (b *BaseRuleContext) GetAltNumber() int {
 
 func (b *BaseRuleContext) SetAltNumber(altNumber int) {}
 
+// GetOuterAltNumber returns the 1-based number of the outer alternative of
+// the rule that the parser chose for this invocation in EnterOuterAlt, or
+// ATNInvalidAltNumber if none was entered. The latter is the case for
+// contexts not created by a parser, and for the contexts a left recursive
+// rule wraps around its left operand when it matches an operator
+// alternative.
+//
+// Unlike GetAltNumber, which contexts may override to store the
+// alternative and have it shown in tree strings as rule:alt, the outer
+// alternative is recorded for every context and not shown.
+func (b *BaseRuleContext) GetOuterAltNumber() int {
+	return b.outerAlt
+}
+
 // A context is empty if there is no invoking state meaning nobody call
 // current context.
 func (b *BaseRuleContext) IsEmpty() bool {
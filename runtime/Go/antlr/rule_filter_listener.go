@@ -0,0 +1,45 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// RuleFilterListener is a ParseTreeListener that calls OnEnter when the walk
+// enters, and OnExit when it leaves, a rule node whose rule index is one of
+// a configured set. Either callback may be nil. All other events are
+// ignored.
+type RuleFilterListener struct {
+	*BaseParseTreeListener
+
+	OnEnter func(ctx ParserRuleContext)
+	OnExit  func(ctx ParserRuleContext)
+
+	ruleIndexes map[int]bool
+}
+
+// NewRuleFilterListener creates a listener that calls onEnter for every rule
+// node with one of ruleIndexes. Set OnExit to also react when such nodes are
+// exited.
+func NewRuleFilterListener(ruleIndexes []int, onEnter func(ctx ParserRuleContext)) *RuleFilterListener {
+	l := &RuleFilterListener{
+		BaseParseTreeListener: &BaseParseTreeListener{},
+		OnEnter:               onEnter,
+		ruleIndexes:           make(map[int]bool),
+	}
+	for _, i := range ruleIndexes {
+		l.ruleIndexes[i] = true
+	}
+	return l
+}
+
+func (l *RuleFilterListener) EnterEveryRule(ctx ParserRuleContext) {
+	if l.OnEnter != nil && l.ruleIndexes[ctx.GetRuleIndex()] {
+		l.OnEnter(ctx)
+	}
+}
+
+func (l *RuleFilterListener) ExitEveryRule(ctx ParserRuleContext) {
+	if l.OnExit != nil && l.ruleIndexes[ctx.GetRuleIndex()] {
+		l.OnExit(ctx)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestRuleFilterListener(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(x) { x = 1; }\ndef g(a, b) { return a*b; }").Prog()
+
+	var entered, exited []string
+	l := NewRuleFilterListener([]int{ExprParserRULE_func_, ExprParserRULE_arg}, func(ctx ParserRuleContext) {
+		entered = append(entered, ctx.GetText())
+	})
+	l.OnExit = func(ctx ParserRuleContext) {
+		exited = append(exited, ctx.GetText())
+	}
+	ParseTreeWalkerDefault.Walk(l, tree)
+
+	assert.Equal([]string{"deff(x){x=1;}", "x", "defg(a,b){returna*b;}", "a", "b"}, entered)
+	assert.Equal([]string{"x", "deff(x){x=1;}", "a", "b", "defg(a,b){returna*b;}"}, exited)
+}
+
+func TestRuleFilterListenerOncePerNode(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(x) { x = 1; }").Prog()
+
+	calls := 0
+	l := NewRuleFilterListener([]int{ExprParserRULE_primary}, func(ctx ParserRuleContext) {
+		calls++
+	})
+	ParseTreeWalkerDefault.Walk(l, tree)
+	assert.Equal(1, calls)
+
+	l = NewRuleFilterListener(nil, func(ctx ParserRuleContext) {
+		calls++
+	})
+	ParseTreeWalkerDefault.Walk(l, tree)
+	assert.Equal(1, calls)
+}
@@ -92,7 +92,19 @@ func (p *Predicate) evaluate(parser Recognizer, outerContext RuleContext) bool {
 		localctx = outerContext
 	}
 
-	return parser.Sempred(localctx, p.ruleIndex, p.predIndex)
+	result := parser.Sempred(localctx, p.ruleIndex, p.predIndex)
+	if o, ok := parser.(predicateObserving); ok {
+		if observer := o.getPredicateObserver(); observer != nil {
+			observer(p.ruleIndex, p.predIndex, result)
+		}
+	}
+	return result
+}
+
+// predicateObserving is implemented by parsers that report the predicates
+// they evaluate, see BaseParser.SetPredicateObserver.
+type predicateObserving interface {
+	getPredicateObserver() func(ruleIndex, predIndex int, result bool)
 }
 
 func (p *Predicate) equals(other interface{}) bool {
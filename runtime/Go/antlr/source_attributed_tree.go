@@ -0,0 +1,101 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// SourceAttributionListener records the source names of the input the nodes
+// of a parse tree come from, for lexers that splice several files into one
+// token stream. The source name of a token is that of the char stream it was
+// lexed from, such as the file name of a FileStream. A rule node comes from
+// the sources of all the tokens below it.
+//
+// The listener can be used in a walk of a complete tree or added to a
+// parser with AddParseListener to attribute the tree while it is built.
+type SourceAttributionListener struct {
+	*BaseParseTreeListener
+
+	files map[interface{}][]string
+}
+
+func NewSourceAttributionListener() *SourceAttributionListener {
+	return &SourceAttributionListener{
+		BaseParseTreeListener: &BaseParseTreeListener{},
+		files:                 make(map[interface{}][]string),
+	}
+}
+
+// FileOf returns the source names of the input node was parsed from, in
+// order of first appearance: one name unless node spans several sources,
+// none for a node that has not been reached yet or holds no tokens.
+func (l *SourceAttributionListener) FileOf(node ParseTree) []string {
+	return l.files[treesNodeKey(node)]
+}
+
+func (l *SourceAttributionListener) VisitTerminal(node TerminalNode) {
+	l.visitToken(node)
+}
+
+func (l *SourceAttributionListener) VisitErrorNode(node ErrorNode) {
+	l.visitToken(node)
+}
+
+func (l *SourceAttributionListener) visitToken(node TerminalNode) {
+	if name, ok := tokenSourceName(node.GetSymbol()); ok {
+		l.files[treesNodeKey(node)] = []string{name}
+	}
+}
+
+func (l *SourceAttributionListener) ExitEveryRule(ctx ParserRuleContext) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, child := range ctx.GetChildren() {
+		for _, name := range l.files[treesNodeKey(child)] {
+			if !seen[name] {
+				seen[name] = true
+				files = append(files, name)
+			}
+		}
+	}
+	if files != nil {
+		l.files[treesNodeKey(ctx)] = files
+	}
+}
+
+// tokenSourceName returns the source name of the char stream t was lexed
+// from, or that of its token source if it has no char stream.
+func tokenSourceName(t Token) (string, bool) {
+	source := t.GetSource()
+	if source == nil {
+		return "", false
+	}
+	if source.charStream != nil {
+		return source.charStream.GetSourceName(), true
+	}
+	if source.tokenSource != nil {
+		return source.tokenSource.GetSourceName(), true
+	}
+	return "", false
+}
+
+// SourceAttributedTree is a parse tree whose nodes know the source names of
+// the input they come from, as recorded by a SourceAttributionListener.
+type SourceAttributedTree struct {
+	ParseTree
+
+	listener *SourceAttributionListener
+}
+
+// NewSourceAttributedTree walks tree to record the sources of its nodes.
+// The tree must not change afterwards.
+func NewSourceAttributedTree(tree ParseTree) *SourceAttributedTree {
+	l := NewSourceAttributionListener()
+	ParseTreeWalkerDefault.Walk(l, tree)
+	return &SourceAttributedTree{ParseTree: tree, listener: l}
+}
+
+// FileOf returns the source names of the input node, which must belong to
+// the tree, was parsed from, as SourceAttributionListener.FileOf does.
+func (s *SourceAttributedTree) FileOf(node ParseTree) []string {
+	return s.listener.FileOf(node)
+}
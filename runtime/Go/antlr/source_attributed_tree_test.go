@@ -0,0 +1,80 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+type sourceAttributionTestStream struct {
+	*InputStream
+
+	name string
+}
+
+func (s *sourceAttributionTestStream) GetSourceName() string {
+	return s.name
+}
+
+// sourceAttributionTestSplicer lexes the files one after the other, as if
+// each one included the next at its end.
+type sourceAttributionTestSplicer struct {
+	Lexer
+
+	rest []Lexer
+}
+
+func newSourceAttributionTestSplicer(files ...string) *sourceAttributionTestSplicer {
+	s := new(sourceAttributionTestSplicer)
+	for i := 0; i < len(files); i += 2 {
+		l := NewExprLexer(&sourceAttributionTestStream{NewInputStream(files[i+1]), files[i]})
+		s.rest = append(s.rest, l)
+	}
+	s.Lexer, s.rest = s.rest[0], s.rest[1:]
+	return s
+}
+
+func (s *sourceAttributionTestSplicer) NextToken() Token {
+	t := s.Lexer.NextToken()
+	if t.GetTokenType() == TokenEOF && len(s.rest) > 0 {
+		s.Lexer, s.rest = s.rest[0], s.rest[1:]
+		return s.NextToken()
+	}
+	return t
+}
+
+func TestSourceAttributedTree(t *testing.T) {
+	assert := assertNew(t)
+	lexer := newSourceAttributionTestSplicer("a.expr", "def f(x) { x;", "b.expr", " }\ndef g(y) { y; }")
+	tree := NewExprParser(NewCommonTokenStream(lexer, TokenDefaultChannel)).Prog()
+	attributed := NewSourceAttributedTree(tree)
+
+	assert.Equal([]string{"a.expr", "b.expr"}, attributed.FileOf(tree))
+	funcs := TreesfindAllRuleNodes(tree, ExprParserRULE_func_)
+	assert.Equal([]string{"a.expr", "b.expr"}, attributed.FileOf(funcs[0]))
+	assert.Equal([]string{"b.expr"}, attributed.FileOf(funcs[1]))
+
+	stat := TreesfindAllRuleNodes(funcs[0], ExprParserRULE_stat)[0]
+	assert.Equal([]string{"a.expr"}, attributed.FileOf(stat))
+	closing := TreesFindAllTokenNodes(funcs[0], ExprLexerT__5)[0]
+	assert.Equal([]string{"b.expr"}, attributed.FileOf(closing))
+	assert.Equal(tree.GetText(), attributed.GetText())
+
+	assert.Nil(attributed.FileOf(newExprParserFor("def h(z) { z; }").Prog()))
+}
+
+func TestSourceAttributionListenerWhileParsing(t *testing.T) {
+	assert := assertNew(t)
+	lexer := newSourceAttributionTestSplicer("a.expr", "def f(x) { x; }", "b.expr", "def g(y) { y; }")
+	p := NewExprParser(NewCommonTokenStream(lexer, TokenDefaultChannel))
+	l := NewSourceAttributionListener()
+	p.AddParseListener(l)
+	tree := p.Prog()
+
+	funcs := TreesfindAllRuleNodes(tree, ExprParserRULE_func_)
+	assert.Equal([]string{"a.expr"}, l.FileOf(funcs[0]))
+	assert.Equal([]string{"b.expr"}, l.FileOf(funcs[1]))
+	assert.Equal([]string{"a.expr", "b.expr"}, l.FileOf(tree))
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+)
+
+// StreamingCharStream is a CharStream that reads its characters from an
+// io.Reader as they are needed instead of loading the whole input, so
+// inputs larger than memory can be lexed.
+//
+// Only a window of the input is retained: the characters from the oldest
+// Mark that has not been released, or from the current position if there
+// is none, up to the furthest character looked at. Seek, GetText and LA
+// panic with a *StreamWindowError if they are asked for characters outside
+// of that window. A lexer marks the
+// start of every token, so the text of the current token is always
+// available while it is matched, but not afterwards: tokens must copy their
+// text when they are created, which the lexer does after
+//
+//	lexer.SetTokenFactory(NewCommonTokenFactory(true))
+//
+// Size is not known before the end of the input has been read and returns
+// -1 until then.
+type StreamingCharStream struct {
+	reader *bufio.Reader
+	name   string
+	err    error
+
+	// data holds the retained window, starting with the character at index
+	// bufferStartIndex of the input.
+	data             []rune
+	bufferStartIndex int
+
+	// p is the position in data of the current character.
+	p int
+
+	numMarkers int
+
+	// lastChar is the character before the current one, returned by LA(-1),
+	// and lastCharBufferStart the character before data[0].
+	lastChar            int
+	lastCharBufferStart int
+
+	eof bool
+}
+
+// NewStreamingCharStream creates a stream that reads UTF-8 encoded
+// characters from r.
+func NewStreamingCharStream(r io.Reader) *StreamingCharStream {
+	s := new(StreamingCharStream)
+	s.reader = bufio.NewReader(r)
+	s.name = "<unknown>"
+	s.lastChar = TokenEOF
+	s.lastCharBufferStart = TokenEOF
+	return s
+}
+
+// SetSourceName sets the name returned by GetSourceName, typically the name
+// of the file being read.
+func (s *StreamingCharStream) SetSourceName(name string) {
+	s.name = name
+}
+
+func (s *StreamingCharStream) GetSourceName() string {
+	return s.name
+}
+
+// Err returns the error, other than io.EOF, that ended reading the input,
+// or nil. The input is considered to end where the error occurred. If
+// reading did not fail, Err returns the first *StreamWindowError panicked
+// by the stream, if any.
+func (s *StreamingCharStream) Err() error {
+	return s.err
+}
+
+// StreamWindowError is panicked by a StreamingCharStream asked for a
+// character at Index outside of the window WindowStart..WindowEnd-1 of the
+// characters it retained. It is also recorded as the Err of the stream.
+type StreamWindowError struct {
+	Index       int
+	WindowStart int
+	WindowEnd   int
+	Source      string
+}
+
+func (e *StreamWindowError) Error() string {
+	return "index " + strconv.Itoa(e.Index) + " is outside of the retained window " +
+		strconv.Itoa(e.WindowStart) + ".." + strconv.Itoa(e.WindowEnd) + " of " + e.Source
+}
+
+// windowError records and panics a StreamWindowError for index.
+func (s *StreamingCharStream) windowError(index int) {
+	err := &StreamWindowError{
+		Index:       index,
+		WindowStart: s.bufferStartIndex,
+		WindowEnd:   s.bufferStartIndex + len(s.data),
+		Source:      s.name,
+	}
+	if s.err == nil {
+		s.err = err
+	}
+	panic(err)
+}
+
+// fill reads characters until data holds at least want characters from the
+// current position on, or the input ends.
+func (s *StreamingCharStream) fill(want int) {
+	for !s.eof && len(s.data)-s.p < want {
+		r, _, err := s.reader.ReadRune()
+		if err != nil {
+			if err != io.EOF {
+				s.err = err
+			}
+			s.eof = true
+			return
+		}
+		s.data = append(s.data, r)
+	}
+}
+
+func (s *StreamingCharStream) Consume() {
+	if s.LA(1) == TokenEOF {
+		panic("cannot consume EOF")
+	}
+	s.lastChar = int(s.data[s.p])
+	s.p++
+	if s.p == len(s.data) && s.numMarkers == 0 {
+		// nothing needs the window anymore, start a new one
+		s.bufferStartIndex += s.p
+		s.data = s.data[:0]
+		s.p = 0
+		s.lastCharBufferStart = s.lastChar
+	}
+}
+
+func (s *StreamingCharStream) LA(offset int) int {
+	if offset == 0 {
+		return 0
+	}
+	if offset == -1 {
+		return s.lastChar
+	}
+	if offset < 0 {
+		offset++
+	}
+	pos := s.p + offset - 1
+	if pos < 0 {
+		s.windowError(s.bufferStartIndex + pos)
+	}
+	s.fill(offset)
+	if pos >= len(s.data) {
+		return TokenEOF
+	}
+	return int(s.data[pos])
+}
+
+func (s *StreamingCharStream) LT(offset int) int {
+	return s.LA(offset)
+}
+
+// Mark keeps the characters from the current position on in the window
+// until the marker is released. Markers must be released in the reverse
+// order of their creation.
+func (s *StreamingCharStream) Mark() int {
+	if s.numMarkers == 0 {
+		s.lastCharBufferStart = s.lastChar
+		s.bufferStartIndex += s.p
+		s.data = append(s.data[:0], s.data[s.p:]...)
+		s.p = 0
+	}
+	s.numMarkers++
+	return -s.numMarkers
+}
+
+func (s *StreamingCharStream) Release(marker int) {
+	if marker != -s.numMarkers {
+		panic("release() called with an invalid marker")
+	}
+	s.numMarkers--
+	if s.numMarkers == 0 && s.p > 0 {
+		// drop the characters before the current one
+		s.bufferStartIndex += s.p
+		s.data = append(s.data[:0], s.data[s.p:]...)
+		s.p = 0
+		s.lastCharBufferStart = s.lastChar
+	}
+}
+
+func (s *StreamingCharStream) Index() int {
+	return s.bufferStartIndex + s.p
+}
+
+// Seek moves to index, which must lie in the retained window. Seeking
+// forward reads the input up to index, stopping at its end.
+func (s *StreamingCharStream) Seek(index int) {
+	if index == s.Index() {
+		return
+	}
+	if index > s.Index() {
+		s.fill(index - s.Index())
+		index = intMin(index, s.bufferStartIndex+len(s.data))
+	}
+	i := index - s.bufferStartIndex
+	if i < 0 || i > len(s.data) {
+		s.windowError(index)
+	}
+	s.p = i
+	if i == 0 {
+		s.lastChar = s.lastCharBufferStart
+	} else {
+		s.lastChar = int(s.data[i-1])
+	}
+}
+
+// Size returns the number of characters of the input, or -1 if the end of
+// the input has not been read yet.
+func (s *StreamingCharStream) Size() int {
+	if !s.eof {
+		return -1
+	}
+	return s.bufferStartIndex + len(s.data)
+}
+
+// GetText returns the characters start..stop, both included, which must lie
+// in the retained window.
+func (s *StreamingCharStream) GetText(start int, stop int) string {
+	if stop < start {
+		return ""
+	}
+	if stop >= s.bufferStartIndex+len(s.data) {
+		s.fill(stop - s.Index() + 1)
+	}
+	if s.eof {
+		stop = intMin(stop, s.bufferStartIndex+len(s.data)-1)
+		if start > stop {
+			return ""
+		}
+	}
+	i, j := start-s.bufferStartIndex, stop-s.bufferStartIndex
+	if i < 0 {
+		s.windowError(start)
+	}
+	if j >= len(s.data) {
+		s.windowError(stop)
+	}
+	return string(s.data[i : j+1])
+}
+
+func (s *StreamingCharStream) GetTextFromTokens(start, stop Token) string {
+	if start != nil && stop != nil {
+		return s.GetText(start.GetStart(), stop.GetStop())
+	}
+
+	return ""
+}
+
+func (s *StreamingCharStream) GetTextFromInterval(i *Interval) string {
+	return s.GetText(i.Start, i.Stop)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStreamingCharStreamLexing(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(x) {\n  x = (1+23)*4;\n  return x;\n}\ndef g(ab, cd) { ab/cd; }\n"
+
+	expected := NewExprLexer(NewInputStream(input)).GetAllTokens()
+
+	stream := NewStreamingCharStream(iotest.OneByteReader(strings.NewReader(input)))
+	l := NewExprLexer(stream)
+	l.SetTokenFactory(NewCommonTokenFactory(true))
+	assert.Equal(-1, stream.Size())
+	tokens := l.GetAllTokens()
+
+	assert.Equal(len(expected), len(tokens))
+	for i, e := range expected {
+		assert.Equal(e.(*CommonToken).String(), tokens[i].(*CommonToken).String())
+	}
+	assert.Equal(len([]rune(input)), stream.Size())
+	assert.Nil(stream.Err())
+
+	// the window only holds what is still needed
+	assert.Equal(true, len(stream.data) <= 1)
+}
+
+func TestStreamingCharStreamLA(t *testing.T) {
+	assert := assertNew(t)
+	stream := NewStreamingCharStream(iotest.HalfReader(strings.NewReader("aé日b")))
+
+	assert.Equal(TokenEOF, stream.LA(-1))
+	assert.Equal('日', rune(stream.LA(3)))
+	assert.Equal('a', rune(stream.LA(1)))
+	stream.Consume()
+	assert.Equal('a', rune(stream.LA(-1)))
+	assert.Equal('é', rune(stream.LA(1)))
+	assert.Equal('b', rune(stream.LA(3)))
+	assert.Equal(TokenEOF, stream.LA(4))
+	assert.Equal(1, stream.Index())
+	assert.Equal(4, stream.Size())
+}
+
+func TestStreamingCharStreamMarkSeek(t *testing.T) {
+	assert := assertNew(t)
+	stream := NewStreamingCharStream(strings.NewReader("abcdefgh"))
+	stream.Consume()
+
+	outer := stream.Mark()
+	stream.Consume()
+	stream.Consume()
+	inner := stream.Mark()
+	stream.Consume()
+	assert.Equal(4, stream.Index())
+	assert.Equal("bcd", stream.GetText(1, 3))
+
+	stream.Seek(1)
+	assert.Equal(1, stream.Index())
+	assert.Equal('b', rune(stream.LA(1)))
+	assert.Equal('a', rune(stream.LA(-1)))
+	stream.Seek(6)
+	assert.Equal('g', rune(stream.LA(1)))
+	assert.Equal('f', rune(stream.LA(-1)))
+	assert.Equal("cdefgh", stream.GetText(2, 20))
+
+	assert.Panics(func() { stream.Release(outer) })
+	stream.Release(inner)
+	stream.Release(outer)
+
+	// the characters before the current one are gone now
+	assert.Equal("g", stream.GetText(6, 6))
+	assert.Panics(func() { stream.Seek(5) })
+	assert.Panics(func() { stream.GetText(5, 6) })
+	assert.Equal('f', rune(stream.LA(-1)))
+
+	stream.Seek(100)
+	assert.Equal(8, stream.Index())
+	assert.Equal(TokenEOF, stream.LA(1))
+	assert.Panics(func() { stream.Consume() })
+}
+
+func TestStreamingCharStreamWindowError(t *testing.T) {
+	assert := assertNew(t)
+	stream := NewStreamingCharStream(strings.NewReader("abcdefgh"))
+	stream.SetSourceName("input.expr")
+	for i := 0; i < 4; i++ {
+		stream.Consume()
+	}
+	assert.Nil(stream.Err())
+
+	recovered := func(f func()) (e interface{}) {
+		defer func() { e = recover() }()
+		f()
+		return nil
+	}
+	err, ok := recovered(func() { stream.Seek(2) }).(*StreamWindowError)
+	assert.Equal(true, ok)
+	assert.Equal(&StreamWindowError{Index: 2, WindowStart: 4, WindowEnd: 4, Source: "input.expr"}, err)
+	assert.Equal("index 2 is outside of the retained window 4..4 of input.expr", err.Error())
+	assert.Equal(err, stream.Err())
+
+	stream.LA(2)
+	err, ok = recovered(func() { stream.GetText(3, 4) }).(*StreamWindowError)
+	assert.Equal(true, ok)
+	assert.Equal(&StreamWindowError{Index: 3, WindowStart: 4, WindowEnd: 6, Source: "input.expr"}, err)
+	_, ok = recovered(func() { stream.LA(-2) }).(*StreamWindowError)
+	assert.Equal(true, ok)
+
+	// Err keeps the first error
+	assert.Equal(2, stream.Err().(*StreamWindowError).Index)
+	assert.Equal("ef", stream.GetText(4, 5))
+}
+
+func TestStreamingCharStreamReadError(t *testing.T) {
+	assert := assertNew(t)
+	stream := NewStreamingCharStream(iotest.TimeoutReader(iotest.OneByteReader(strings.NewReader("ab"))))
+	stream.SetSourceName("input.expr")
+
+	assert.Equal('a', rune(stream.LA(1)))
+	assert.Equal(TokenEOF, stream.LA(2))
+	assert.Equal(iotest.ErrTimeout, stream.Err())
+	assert.Equal(1, stream.Size())
+	assert.Equal("input.expr", stream.GetSourceName())
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+/*
+ExprLexer is a lexer for testing purpose.
+
+This file is generated from this grammar (see also testing_parser_expr_test.go).
+
+grammar Expr;
+...
+MUL :   '*' ;
+DIV :   '/' ;
+ADD :   '+' ;
+SUB :   '-' ;
+RETURN : 'return' ;
+ID  :   [a-zA-Z]+ ;
+INT :   [0-9]+ ;
+NEWLINE:'\r'? '\n' -> skip;
+WS  :   [ \t]+ -> skip ;
+*/
+
+var exprLexer_serializedLexerAtn = []uint16{
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 19, 94,
+	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4,
+	7, 9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9,
+	12, 4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9,
+	17, 4, 18, 9, 18, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 4, 3, 4, 3,
+	5, 3, 5, 3, 6, 3, 6, 3, 7, 3, 7, 3, 8, 3, 8, 3, 9, 3, 9, 3, 10, 3, 10,
+	3, 11, 3, 11, 3, 12, 3, 12, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 3, 14,
+	3, 14, 3, 14, 3, 14, 3, 15, 6, 15, 72, 10, 15, 13, 15, 14, 15, 73, 3,
+	16, 6, 16, 77, 10, 16, 13, 16, 14, 16, 78, 3, 17, 5, 17, 82, 10, 17,
+	3, 17, 3, 17, 3, 17, 3, 17, 3, 18, 6, 18, 89, 10, 18, 13, 18, 14, 18,
+	90, 3, 18, 3, 18, 2, 2, 19, 3, 3, 5, 4, 7, 5, 9, 6, 11, 7, 13, 8, 15,
+	9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 15, 29, 16, 31, 17, 33,
+	18, 35, 19, 3, 2, 5, 4, 2, 67, 92, 99, 124, 3, 2, 50, 59, 4, 2, 11,
+	11, 34, 34, 2, 97, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2,
+	2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3,
+	2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2,
+	23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2,
+	2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 3, 37, 3,
+	2, 2, 2, 5, 41, 3, 2, 2, 2, 7, 43, 3, 2, 2, 2, 9, 45, 3, 2, 2, 2, 11,
+	47, 3, 2, 2, 2, 13, 49, 3, 2, 2, 2, 15, 51, 3, 2, 2, 2, 17, 53, 3, 2,
+	2, 2, 19, 55, 3, 2, 2, 2, 21, 57, 3, 2, 2, 2, 23, 59, 3, 2, 2, 2, 25,
+	61, 3, 2, 2, 2, 27, 63, 3, 2, 2, 2, 29, 71, 3, 2, 2, 2, 31, 76, 3, 2,
+	2, 2, 33, 81, 3, 2, 2, 2, 35, 88, 3, 2, 2, 2, 37, 38, 7, 102, 2, 2,
+	38, 39, 7, 103, 2, 2, 39, 40, 7, 104, 2, 2, 40, 4, 3, 2, 2, 2, 41, 42,
+	7, 42, 2, 2, 42, 6, 3, 2, 2, 2, 43, 44, 7, 46, 2, 2, 44, 8, 3, 2, 2,
+	2, 45, 46, 7, 43, 2, 2, 46, 10, 3, 2, 2, 2, 47, 48, 7, 125, 2, 2, 48,
+	12, 3, 2, 2, 2, 49, 50, 7, 127, 2, 2, 50, 14, 3, 2, 2, 2, 51, 52, 7,
+	61, 2, 2, 52, 16, 3, 2, 2, 2, 53, 54, 7, 63, 2, 2, 54, 18, 3, 2, 2, 2,
+	55, 56, 7, 44, 2, 2, 56, 20, 3, 2, 2, 2, 57, 58, 7, 49, 2, 2, 58, 22,
+	3, 2, 2, 2, 59, 60, 7, 45, 2, 2, 60, 24, 3, 2, 2, 2, 61, 62, 7, 47, 2,
+	2, 62, 26, 3, 2, 2, 2, 63, 64, 7, 116, 2, 2, 64, 65, 7, 103, 2, 2, 65,
+	66, 7, 118, 2, 2, 66, 67, 7, 119, 2, 2, 67, 68, 7, 116, 2, 2, 68, 69,
+	7, 112, 2, 2, 69, 28, 3, 2, 2, 2, 70, 72, 9, 2, 2, 2, 71, 70, 3, 2, 2,
+	2, 72, 73, 3, 2, 2, 2, 73, 71, 3, 2, 2, 2, 73, 74, 3, 2, 2, 2, 74, 30,
+	3, 2, 2, 2, 75, 77, 9, 3, 2, 2, 76, 75, 3, 2, 2, 2, 77, 78, 3, 2, 2,
+	2, 78, 76, 3, 2, 2, 2, 78, 79, 3, 2, 2, 2, 79, 32, 3, 2, 2, 2, 80, 82,
+	7, 15, 2, 2, 81, 80, 3, 2, 2, 2, 81, 82, 3, 2, 2, 2, 82, 83, 3, 2, 2,
+	2, 83, 84, 7, 12, 2, 2, 84, 85, 3, 2, 2, 2, 85, 86, 8, 17, 2, 2, 86,
+	34, 3, 2, 2, 2, 87, 89, 9, 4, 2, 2, 88, 87, 3, 2, 2, 2, 89, 90, 3, 2,
+	2, 2, 90, 88, 3, 2, 2, 2, 90, 91, 3, 2, 2, 2, 91, 92, 3, 2, 2, 2, 92,
+	93, 8, 18, 2, 2, 93, 36, 3, 2, 2, 2, 7, 2, 73, 78, 81, 90, 3, 8, 2, 2,
+}
+
+var exprLexer_lexerDeserializer = NewATNDeserializer(nil)
+var exprLexer_lexerAtn = exprLexer_lexerDeserializer.DeserializeFromUInt16(exprLexer_serializedLexerAtn)
+
+var exprLexer_lexerChannelNames = []string{
+	"DEFAULT_TOKEN_CHANNEL", "HIDDEN",
+}
+
+var exprLexer_lexerModeNames = []string{
+	"DEFAULT_MODE",
+}
+
+var exprLexer_lexerLiteralNames = []string{
+	"", "'def'", "'('", "','", "')'", "'{'", "'}'", "';'", "'='", "'*'", "'/'",
+	"'+'", "'-'", "'return'",
+}
+
+var exprLexer_lexerSymbolicNames = []string{
+	"", "", "", "", "", "", "", "", "", "MUL", "DIV", "ADD", "SUB", "RETURN",
+	"ID", "INT", "NEWLINE", "WS",
+}
+
+var exprLexer_lexerRuleNames = []string{
+	"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "T__7", "MUL",
+	"DIV", "ADD", "SUB", "RETURN", "ID", "INT", "NEWLINE", "WS",
+}
+
+type ExprLexer struct {
+	*BaseLexer
+	channelNames []string
+	modeNames    []string
+	// TODO: EOF string
+}
+
+var exprLexer_lexerDecisionToDFA = make([]*DFA, len(exprLexer_lexerAtn.DecisionToState))
+
+func init() {
+	for index, ds := range exprLexer_lexerAtn.DecisionToState {
+		exprLexer_lexerDecisionToDFA[index] = NewDFA(ds, index)
+	}
+}
+
+func NewExprLexer(input CharStream) *ExprLexer {
+	l := new(ExprLexer)
+
+	l.BaseLexer = NewBaseLexer(input)
+	l.Interpreter = NewLexerATNSimulator(l, exprLexer_lexerAtn, exprLexer_lexerDecisionToDFA, NewPredictionContextCache())
+
+	l.channelNames = exprLexer_lexerChannelNames
+	l.modeNames = exprLexer_lexerModeNames
+	l.RuleNames = exprLexer_lexerRuleNames
+	l.LiteralNames = exprLexer_lexerLiteralNames
+	l.SymbolicNames = exprLexer_lexerSymbolicNames
+	l.GrammarFileName = "Expr.g4"
+	// TODO: l.EOF = TokenEOF
+
+	return l
+}
+
+// ExprLexer tokens.
+const (
+	ExprLexerT__0    = 1
+	ExprLexerT__1    = 2
+	ExprLexerT__2    = 3
+	ExprLexerT__3    = 4
+	ExprLexerT__4    = 5
+	ExprLexerT__5    = 6
+	ExprLexerT__6    = 7
+	ExprLexerT__7    = 8
+	ExprLexerMUL     = 9
+	ExprLexerDIV     = 10
+	ExprLexerADD     = 11
+	ExprLexerSUB     = 12
+	ExprLexerRETURN  = 13
+	ExprLexerID      = 14
+	ExprLexerINT     = 15
+	ExprLexerNEWLINE = 16
+	ExprLexerWS      = 17
+)
+
+// Comment tokens produced by exprCommentLexer.
+const (
+	exprCommentLexerCOMMENT        = 18
+	exprCommentLexerCommentChannel = 2
+)
+
+// exprCommentLexer is an ExprLexer that also recognizes line comments
+// starting with '//', which it emits as COMMENT tokens on
+// exprCommentLexerCommentChannel.
+type exprCommentLexer struct {
+	*ExprLexer
+}
+
+func newExprCommentLexer(input string) *exprCommentLexer {
+	l := &exprCommentLexer{NewExprLexer(NewInputStream(input))}
+	l.Virt = l
+	return l
+}
+
+func (l *exprCommentLexer) NextToken() Token {
+	t := l.ExprLexer.NextToken()
+	if t.GetTokenType() != ExprLexerDIV || l.input.LA(1) != '/' {
+		return t
+	}
+	for l.input.LA(1) != '\n' && l.input.LA(1) != TokenEOF {
+		l.Interpreter.Consume(l.input)
+	}
+	if l.input.LA(1) == TokenEOF {
+		l.hitEOF = true
+	}
+	l.token = l.factory.Create(l.tokenFactorySourcePair, exprCommentLexerCOMMENT, "", exprCommentLexerCommentChannel,
+		t.GetStart(), l.input.Index()-1, t.GetLine(), t.GetColumn())
+	return l.token
+}
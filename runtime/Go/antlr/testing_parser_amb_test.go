@@ -0,0 +1,84 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// ambParser is a parser for the deliberately ambiguous grammar
+//
+/*
+grammar Amb;
+
+s   :   ID ID
+    |   ID ID
+    ;
+*/
+//
+// using the tokens of ExprLexer. Any input "ID ID" matches both
+// alternatives of s, so full context prediction reports an ambiguity.
+
+var ambParser_serializedATN = []uint16{
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 19, 10,
+	4, 2, 9, 2, 5, 2, 9, 3, 2, 3, 2, 3, 2, 3, 2, 10, 2, 2, 2, 3, 2, 2, 2,
+	2, 10, 2, 4, 3, 2, 2, 2, 4, 5, 3, 2, 2, 2, 4, 7, 3, 2, 2, 2, 5, 6, 7,
+	16, 2, 2, 6, 9, 7, 16, 2, 2, 7, 8, 7, 16, 2, 2, 8, 9, 7, 16, 2, 2, 9,
+	3, 3, 2, 2, 2, 3, 4,
+}
+
+var ambParser_ruleNames = []string{
+	"s",
+}
+
+type ambParser struct {
+	*BaseParser
+}
+
+func newAmbParser(input TokenStream) *ambParser {
+	this := new(ambParser)
+
+	deserializer := NewATNDeserializer(nil)
+	deserializedATN := deserializer.DeserializeFromUInt16(ambParser_serializedATN)
+	decisionToDFA := make([]*DFA, len(deserializedATN.DecisionToState))
+	for index, ds := range deserializedATN.DecisionToState {
+		decisionToDFA[index] = NewDFA(ds, index)
+	}
+
+	this.BaseParser = NewBaseParser(input)
+
+	this.Interpreter = NewParserATNSimulator(this, deserializedATN, decisionToDFA, NewPredictionContextCache())
+	this.RuleNames = ambParser_ruleNames
+	this.LiteralNames = exprParser_literalNames
+	this.SymbolicNames = exprParser_symbolicNames
+	this.GrammarFileName = "Amb.g4"
+
+	return this
+}
+
+func (p *ambParser) S() (localctx ParserRuleContext) {
+	localctx = NewBaseParserRuleContext(p.GetParserRuleContext(), p.GetState())
+	localctx.(*BaseParserRuleContext).RuleIndex = 0
+	p.EnterRule(localctx, 0, 0)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	p.SetState(2)
+	alt := p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 0, p.GetParserRuleContext())
+	p.EnterOuterAlt(localctx, alt)
+	switch alt {
+	case 1:
+		p.SetState(3)
+		p.Match(ExprLexerID)
+		p.SetState(4)
+		p.Match(ExprLexerID)
+
+	case 2:
+		p.SetState(5)
+		p.Match(ExprLexerID)
+		p.SetState(6)
+		p.Match(ExprLexerID)
+	}
+
+	return localctx
+}
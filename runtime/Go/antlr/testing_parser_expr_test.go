@@ -0,0 +1,1288 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/*
+ExprParser is a parser for testing purpose.
+
+This file is generated from this grammar (the lexer rules are in
+testing_lexer_expr_test.go).
+
+grammar Expr;
+
+prog:   func+ ;
+
+func : 'def' ID '(' arg (',' arg)* ')' body ;
+
+body:   '{' stat+ '}' ;
+
+arg :   ID ;
+
+stat:   expr ';'                 # printExpr
+    |   ID '=' expr ';'          # assign
+    |   'return' expr ';'        # ret
+    |   ';'                      # blank
+    ;
+
+expr:   expr ('*'|'/') expr      # MulDiv
+    |   expr ('+'|'-') expr      # AddSub
+    |   primary                  # prim
+    ;
+
+primary
+    :   INT                      # int
+    |   ID                       # id
+    |   '(' expr ')'             # parens
+    ;
+*/
+
+var exprParser_serializedATN = []uint16{
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 19, 83,
+	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9,
+	7, 4, 8, 9, 8, 3, 2, 6, 2, 18, 10, 2, 13, 2, 14, 2, 19, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 7, 3, 28, 10, 3, 12, 3, 14, 3, 31, 11, 3, 3, 3,
+	3, 3, 3, 3, 3, 4, 3, 4, 6, 4, 38, 10, 4, 13, 4, 14, 4, 39, 3, 4, 3, 4,
+	3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3,
+	6, 3, 6, 3, 6, 3, 6, 5, 6, 59, 10, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3,
+	7, 3, 7, 3, 7, 3, 7, 7, 7, 70, 10, 7, 12, 7, 14, 7, 73, 11, 7, 3, 8,
+	3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 5, 8, 81, 10, 8, 3, 8, 2, 3, 12, 9, 2,
+	4, 6, 8, 10, 12, 14, 2, 4, 3, 2, 11, 12, 3, 2, 13, 14, 2, 85, 2, 17,
+	3, 2, 2, 2, 4, 21, 3, 2, 2, 2, 6, 35, 3, 2, 2, 2, 8, 43, 3, 2, 2, 2,
+	10, 58, 3, 2, 2, 2, 12, 60, 3, 2, 2, 2, 14, 80, 3, 2, 2, 2, 16, 18, 5,
+	4, 3, 2, 17, 16, 3, 2, 2, 2, 18, 19, 3, 2, 2, 2, 19, 17, 3, 2, 2, 2,
+	19, 20, 3, 2, 2, 2, 20, 3, 3, 2, 2, 2, 21, 22, 7, 3, 2, 2, 22, 23, 7,
+	16, 2, 2, 23, 24, 7, 4, 2, 2, 24, 29, 5, 8, 5, 2, 25, 26, 7, 5, 2, 2,
+	26, 28, 5, 8, 5, 2, 27, 25, 3, 2, 2, 2, 28, 31, 3, 2, 2, 2, 29, 27, 3,
+	2, 2, 2, 29, 30, 3, 2, 2, 2, 30, 32, 3, 2, 2, 2, 31, 29, 3, 2, 2, 2,
+	32, 33, 7, 6, 2, 2, 33, 34, 5, 6, 4, 2, 34, 5, 3, 2, 2, 2, 35, 37, 7,
+	7, 2, 2, 36, 38, 5, 10, 6, 2, 37, 36, 3, 2, 2, 2, 38, 39, 3, 2, 2, 2,
+	39, 37, 3, 2, 2, 2, 39, 40, 3, 2, 2, 2, 40, 41, 3, 2, 2, 2, 41, 42, 7,
+	8, 2, 2, 42, 7, 3, 2, 2, 2, 43, 44, 7, 16, 2, 2, 44, 9, 3, 2, 2, 2,
+	45, 46, 5, 12, 7, 2, 46, 47, 7, 9, 2, 2, 47, 59, 3, 2, 2, 2, 48, 49,
+	7, 16, 2, 2, 49, 50, 7, 10, 2, 2, 50, 51, 5, 12, 7, 2, 51, 52, 7, 9,
+	2, 2, 52, 59, 3, 2, 2, 2, 53, 54, 7, 15, 2, 2, 54, 55, 5, 12, 7, 2,
+	55, 56, 7, 9, 2, 2, 56, 59, 3, 2, 2, 2, 57, 59, 7, 9, 2, 2, 58, 45, 3,
+	2, 2, 2, 58, 48, 3, 2, 2, 2, 58, 53, 3, 2, 2, 2, 58, 57, 3, 2, 2, 2,
+	59, 11, 3, 2, 2, 2, 60, 61, 8, 7, 1, 2, 61, 62, 5, 14, 8, 2, 62, 71,
+	3, 2, 2, 2, 63, 64, 12, 5, 2, 2, 64, 65, 9, 2, 2, 2, 65, 70, 5, 12, 7,
+	6, 66, 67, 12, 4, 2, 2, 67, 68, 9, 3, 2, 2, 68, 70, 5, 12, 7, 5, 69,
+	63, 3, 2, 2, 2, 69, 66, 3, 2, 2, 2, 70, 73, 3, 2, 2, 2, 71, 69, 3, 2,
+	2, 2, 71, 72, 3, 2, 2, 2, 72, 13, 3, 2, 2, 2, 73, 71, 3, 2, 2, 2, 74,
+	81, 7, 17, 2, 2, 75, 81, 7, 16, 2, 2, 76, 77, 7, 4, 2, 2, 77, 78, 5,
+	12, 7, 2, 78, 79, 7, 6, 2, 2, 79, 81, 3, 2, 2, 2, 80, 74, 3, 2, 2, 2,
+	80, 75, 3, 2, 2, 2, 80, 76, 3, 2, 2, 2, 81, 15, 3, 2, 2, 2, 9, 19, 29,
+	39, 58, 69, 71, 80,
+}
+
+var exprParser_literalNames = []string{
+	"", "'def'", "'('", "','", "')'", "'{'", "'}'", "';'", "'='", "'*'", "'/'",
+	"'+'", "'-'", "'return'",
+}
+
+var exprParser_symbolicNames = []string{
+	"", "", "", "", "", "", "", "", "", "MUL", "DIV", "ADD", "SUB", "RETURN",
+	"ID", "INT", "NEWLINE", "WS",
+}
+
+var exprParser_ruleNames = []string{
+	"prog", "func_", "body", "arg", "stat", "expr", "primary",
+}
+
+type ExprParser struct {
+	*BaseParser
+}
+
+func NewExprParser(input TokenStream) *ExprParser {
+	this := new(ExprParser)
+
+	deserializer := NewATNDeserializer(nil)
+	deserializedATN := deserializer.DeserializeFromUInt16(exprParser_serializedATN)
+	decisionToDFA := make([]*DFA, len(deserializedATN.DecisionToState))
+	for index, ds := range deserializedATN.DecisionToState {
+		decisionToDFA[index] = NewDFA(ds, index)
+	}
+
+	this.BaseParser = NewBaseParser(input)
+
+	this.Interpreter = NewParserATNSimulator(this, deserializedATN, decisionToDFA, NewPredictionContextCache())
+	this.RuleNames = exprParser_ruleNames
+	this.LiteralNames = exprParser_literalNames
+	this.SymbolicNames = exprParser_symbolicNames
+	this.GrammarFileName = "Expr.g4"
+
+	return this
+}
+
+// ExprParser tokens.
+const (
+	ExprParserEOF     = TokenEOF
+	ExprParserT__0    = 1
+	ExprParserT__1    = 2
+	ExprParserT__2    = 3
+	ExprParserT__3    = 4
+	ExprParserT__4    = 5
+	ExprParserT__5    = 6
+	ExprParserT__6    = 7
+	ExprParserT__7    = 8
+	ExprParserMUL     = 9
+	ExprParserDIV     = 10
+	ExprParserADD     = 11
+	ExprParserSUB     = 12
+	ExprParserRETURN  = 13
+	ExprParserID      = 14
+	ExprParserINT     = 15
+	ExprParserNEWLINE = 16
+	ExprParserWS      = 17
+)
+
+// ExprParser rules.
+const (
+	ExprParserRULE_prog    = 0
+	ExprParserRULE_func_   = 1
+	ExprParserRULE_body    = 2
+	ExprParserRULE_arg     = 3
+	ExprParserRULE_stat    = 4
+	ExprParserRULE_expr    = 5
+	ExprParserRULE_primary = 6
+)
+
+// exprParserRecover is the error handling shared by the rule functions below.
+func exprParserRecover(p *ExprParser, localctx ParserRuleContext) {
+	if err := recover(); err != nil {
+		if v, ok := err.(RecognitionException); ok {
+			localctx.SetException(v)
+			p.GetErrorHandler().ReportError(p, v)
+			p.GetErrorHandler().Recover(p, v)
+		} else {
+			panic(err)
+		}
+	}
+}
+
+// IProgContext is an interface to support dynamic dispatch.
+type IProgContext interface {
+	ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() Parser
+
+	// IsProgContext differentiates from other interfaces.
+	IsProgContext()
+}
+
+type ProgContext struct {
+	*BaseParserRuleContext
+	parser Parser
+}
+
+func NewEmptyProgContext() *ProgContext {
+	var p = new(ProgContext)
+	p.BaseParserRuleContext = NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExprParserRULE_prog
+	return p
+}
+
+func (*ProgContext) IsProgContext() {}
+
+func NewProgContext(parser Parser, parent ParserRuleContext, invokingState int) *ProgContext {
+	var p = new(ProgContext)
+
+	p.BaseParserRuleContext = NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExprParserRULE_prog
+
+	return p
+}
+
+func (s *ProgContext) GetParser() Parser { return s.parser }
+
+func (s *ProgContext) AllFunc_() []IFunc_Context {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFunc_Context)(nil)).Elem())
+	var tst = make([]IFunc_Context, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFunc_Context)
+		}
+	}
+
+	return tst
+}
+
+func (s *ProgContext) Func_(i int) IFunc_Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFunc_Context)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFunc_Context)
+}
+
+func (s *ProgContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *ProgContext) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(s, ruleNames, recog)
+}
+
+func (p *ExprParser) Prog() (localctx IProgContext) {
+	localctx = NewProgContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 0, ExprParserRULE_prog)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer exprParserRecover(p, localctx)
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(15)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = _la == ExprParserT__0 {
+		{
+			p.SetState(14)
+			p.Func_()
+		}
+
+		p.SetState(17)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IFunc_Context is an interface to support dynamic dispatch.
+type IFunc_Context interface {
+	ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() Parser
+
+	// IsFunc_Context differentiates from other interfaces.
+	IsFunc_Context()
+}
+
+type Func_Context struct {
+	*BaseParserRuleContext
+	parser Parser
+}
+
+func NewEmptyFunc_Context() *Func_Context {
+	var p = new(Func_Context)
+	p.BaseParserRuleContext = NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExprParserRULE_func_
+	return p
+}
+
+func (*Func_Context) IsFunc_Context() {}
+
+func NewFunc_Context(parser Parser, parent ParserRuleContext, invokingState int) *Func_Context {
+	var p = new(Func_Context)
+
+	p.BaseParserRuleContext = NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExprParserRULE_func_
+
+	return p
+}
+
+func (s *Func_Context) GetParser() Parser { return s.parser }
+
+func (s *Func_Context) ID() TerminalNode {
+	return s.GetToken(ExprParserID, 0)
+}
+
+func (s *Func_Context) AllArg() []IArgContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IArgContext)(nil)).Elem())
+	var tst = make([]IArgContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IArgContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *Func_Context) Arg(i int) IArgContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IArgContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArgContext)
+}
+
+func (s *Func_Context) Body() IBodyContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBodyContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBodyContext)
+}
+
+func (s *Func_Context) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *Func_Context) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(s, ruleNames, recog)
+}
+
+func (p *ExprParser) Func_() (localctx IFunc_Context) {
+	localctx = NewFunc_Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 2, ExprParserRULE_func_)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer exprParserRecover(p, localctx)
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(19)
+		p.Match(ExprParserT__0)
+	}
+	{
+		p.SetState(20)
+		p.Match(ExprParserID)
+	}
+	{
+		p.SetState(21)
+		p.Match(ExprParserT__1)
+	}
+	{
+		p.SetState(22)
+		p.Arg()
+	}
+	p.SetState(27)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == ExprParserT__2 {
+		{
+			p.SetState(23)
+			p.Match(ExprParserT__2)
+		}
+		{
+			p.SetState(24)
+			p.Arg()
+		}
+
+		p.SetState(29)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(30)
+		p.Match(ExprParserT__3)
+	}
+	{
+		p.SetState(31)
+		p.Body()
+	}
+
+	return localctx
+}
+
+// IBodyContext is an interface to support dynamic dispatch.
+type IBodyContext interface {
+	ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() Parser
+
+	// IsBodyContext differentiates from other interfaces.
+	IsBodyContext()
+}
+
+type BodyContext struct {
+	*BaseParserRuleContext
+	parser Parser
+}
+
+func NewEmptyBodyContext() *BodyContext {
+	var p = new(BodyContext)
+	p.BaseParserRuleContext = NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExprParserRULE_body
+	return p
+}
+
+func (*BodyContext) IsBodyContext() {}
+
+func NewBodyContext(parser Parser, parent ParserRuleContext, invokingState int) *BodyContext {
+	var p = new(BodyContext)
+
+	p.BaseParserRuleContext = NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExprParserRULE_body
+
+	return p
+}
+
+func (s *BodyContext) GetParser() Parser { return s.parser }
+
+func (s *BodyContext) AllStat() []IStatContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IStatContext)(nil)).Elem())
+	var tst = make([]IStatContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IStatContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *BodyContext) Stat(i int) IStatContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStatContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStatContext)
+}
+
+func (s *BodyContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *BodyContext) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(s, ruleNames, recog)
+}
+
+func (p *ExprParser) Body() (localctx IBodyContext) {
+	localctx = NewBodyContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 4, ExprParserRULE_body)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer exprParserRecover(p, localctx)
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(33)
+		p.Match(ExprParserT__4)
+	}
+	p.SetState(35)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<ExprParserT__1)|(1<<ExprParserT__6)|(1<<ExprParserRETURN)|(1<<ExprParserID)|(1<<ExprParserINT))) != 0) {
+		{
+			p.SetState(34)
+			p.Stat()
+		}
+
+		p.SetState(37)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(39)
+		p.Match(ExprParserT__5)
+	}
+
+	return localctx
+}
+
+// IArgContext is an interface to support dynamic dispatch.
+type IArgContext interface {
+	ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() Parser
+
+	// IsArgContext differentiates from other interfaces.
+	IsArgContext()
+}
+
+type ArgContext struct {
+	*BaseParserRuleContext
+	parser Parser
+}
+
+func NewEmptyArgContext() *ArgContext {
+	var p = new(ArgContext)
+	p.BaseParserRuleContext = NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExprParserRULE_arg
+	return p
+}
+
+func (*ArgContext) IsArgContext() {}
+
+func NewArgContext(parser Parser, parent ParserRuleContext, invokingState int) *ArgContext {
+	var p = new(ArgContext)
+
+	p.BaseParserRuleContext = NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExprParserRULE_arg
+
+	return p
+}
+
+func (s *ArgContext) GetParser() Parser { return s.parser }
+
+func (s *ArgContext) ID() TerminalNode {
+	return s.GetToken(ExprParserID, 0)
+}
+
+func (s *ArgContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *ArgContext) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(s, ruleNames, recog)
+}
+
+func (p *ExprParser) Arg() (localctx IArgContext) {
+	localctx = NewArgContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, ExprParserRULE_arg)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer exprParserRecover(p, localctx)
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(41)
+		p.Match(ExprParserID)
+	}
+
+	return localctx
+}
+
+// IStatContext is an interface to support dynamic dispatch.
+type IStatContext interface {
+	ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() Parser
+
+	// IsStatContext differentiates from other interfaces.
+	IsStatContext()
+}
+
+type StatContext struct {
+	*BaseParserRuleContext
+	parser Parser
+}
+
+func NewEmptyStatContext() *StatContext {
+	var p = new(StatContext)
+	p.BaseParserRuleContext = NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExprParserRULE_stat
+	return p
+}
+
+func (*StatContext) IsStatContext() {}
+
+func NewStatContext(parser Parser, parent ParserRuleContext, invokingState int) *StatContext {
+	var p = new(StatContext)
+
+	p.BaseParserRuleContext = NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExprParserRULE_stat
+
+	return p
+}
+
+func (s *StatContext) GetParser() Parser { return s.parser }
+
+func (s *StatContext) CopyFrom(ctx *StatContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *StatContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *StatContext) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(s, ruleNames, recog)
+}
+
+type PrintExprContext struct {
+	*StatContext
+}
+
+func NewPrintExprContext(parser Parser, ctx ParserRuleContext) *PrintExprContext {
+	var p = new(PrintExprContext)
+
+	p.StatContext = NewEmptyStatContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatContext))
+
+	return p
+}
+
+func (s *PrintExprContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *PrintExprContext) Expr() IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprContext)
+}
+
+type AssignContext struct {
+	*StatContext
+}
+
+func NewAssignContext(parser Parser, ctx ParserRuleContext) *AssignContext {
+	var p = new(AssignContext)
+
+	p.StatContext = NewEmptyStatContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatContext))
+
+	return p
+}
+
+func (s *AssignContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *AssignContext) ID() TerminalNode {
+	return s.GetToken(ExprParserID, 0)
+}
+
+func (s *AssignContext) Expr() IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprContext)
+}
+
+type RetContext struct {
+	*StatContext
+}
+
+func NewRetContext(parser Parser, ctx ParserRuleContext) *RetContext {
+	var p = new(RetContext)
+
+	p.StatContext = NewEmptyStatContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatContext))
+
+	return p
+}
+
+func (s *RetContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *RetContext) RETURN() TerminalNode {
+	return s.GetToken(ExprParserRETURN, 0)
+}
+
+func (s *RetContext) Expr() IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprContext)
+}
+
+type BlankContext struct {
+	*StatContext
+}
+
+func NewBlankContext(parser Parser, ctx ParserRuleContext) *BlankContext {
+	var p = new(BlankContext)
+
+	p.StatContext = NewEmptyStatContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatContext))
+
+	return p
+}
+
+func (s *BlankContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (p *ExprParser) Stat() (localctx IStatContext) {
+	localctx = NewStatContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, ExprParserRULE_stat)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer exprParserRecover(p, localctx)
+
+	p.SetState(56)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewPrintExprContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(43)
+			p.expr(0)
+		}
+		{
+			p.SetState(44)
+			p.Match(ExprParserT__6)
+		}
+
+	case 2:
+		localctx = NewAssignContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(46)
+			p.Match(ExprParserID)
+		}
+		{
+			p.SetState(47)
+			p.Match(ExprParserT__7)
+		}
+		{
+			p.SetState(48)
+			p.expr(0)
+		}
+		{
+			p.SetState(49)
+			p.Match(ExprParserT__6)
+		}
+
+	case 3:
+		localctx = NewRetContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(51)
+			p.Match(ExprParserRETURN)
+		}
+		{
+			p.SetState(52)
+			p.expr(0)
+		}
+		{
+			p.SetState(53)
+			p.Match(ExprParserT__6)
+		}
+
+	case 4:
+		localctx = NewBlankContext(p, localctx)
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(55)
+			p.Match(ExprParserT__6)
+		}
+
+	}
+
+	return localctx
+}
+
+// IExprContext is an interface to support dynamic dispatch.
+type IExprContext interface {
+	ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() Parser
+
+	// IsExprContext differentiates from other interfaces.
+	IsExprContext()
+}
+
+type ExprContext struct {
+	*BaseParserRuleContext
+	parser Parser
+}
+
+func NewEmptyExprContext() *ExprContext {
+	var p = new(ExprContext)
+	p.BaseParserRuleContext = NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExprParserRULE_expr
+	return p
+}
+
+func (*ExprContext) IsExprContext() {}
+
+func NewExprContext(parser Parser, parent ParserRuleContext, invokingState int) *ExprContext {
+	var p = new(ExprContext)
+
+	p.BaseParserRuleContext = NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExprParserRULE_expr
+
+	return p
+}
+
+func (s *ExprContext) GetParser() Parser { return s.parser }
+
+func (s *ExprContext) CopyFrom(ctx *ExprContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *ExprContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *ExprContext) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(s, ruleNames, recog)
+}
+
+type PrimContext struct {
+	*ExprContext
+}
+
+func NewPrimContext(parser Parser, ctx ParserRuleContext) *PrimContext {
+	var p = new(PrimContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *PrimContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *PrimContext) Primary() IPrimaryContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IPrimaryContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPrimaryContext)
+}
+
+type MulDivContext struct {
+	*ExprContext
+}
+
+func NewMulDivContext(parser Parser, ctx ParserRuleContext) *MulDivContext {
+	var p = new(MulDivContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *MulDivContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *MulDivContext) AllExpr() []IExprContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExprContext)(nil)).Elem())
+	var tst = make([]IExprContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExprContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *MulDivContext) Expr(i int) IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprContext)
+}
+
+type AddSubContext struct {
+	*ExprContext
+}
+
+func NewAddSubContext(parser Parser, ctx ParserRuleContext) *AddSubContext {
+	var p = new(AddSubContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *AddSubContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *AddSubContext) AllExpr() []IExprContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExprContext)(nil)).Elem())
+	var tst = make([]IExprContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExprContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *AddSubContext) Expr(i int) IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprContext)
+}
+
+func (p *ExprParser) Expr() (localctx IExprContext) {
+	return p.expr(0)
+}
+
+func (p *ExprParser) expr(_p int) (localctx IExprContext) {
+	var _parentctx ParserRuleContext = p.GetParserRuleContext()
+	_parentState := p.GetState()
+	localctx = NewExprContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IExprContext = localctx
+	var _ ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
+	_startState := 10
+	p.EnterRecursionRule(localctx, 10, ExprParserRULE_expr, _p)
+	var _la int
+
+	defer func() {
+		p.UnrollRecursionContexts(_parentctx)
+	}()
+
+	defer exprParserRecover(p, localctx)
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	localctx = NewPrimContext(p, localctx)
+	p.SetParserRuleContext(localctx)
+	_prevctx = localctx
+
+	{
+		p.SetState(59)
+		p.Primary()
+	}
+
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(69)
+	p.GetErrorHandler().Sync(p)
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 5, p.GetParserRuleContext())
+
+	for _alt != 2 && _alt != ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(67)
+			p.GetErrorHandler().Sync(p)
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewMulDivContext(p, NewExprContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, ExprParserRULE_expr)
+				p.SetState(61)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
+					panic(NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
+				}
+				{
+					p.SetState(62)
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == ExprParserMUL || _la == ExprParserDIV) {
+						p.GetErrorHandler().RecoverInline(p)
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(63)
+					p.expr(4)
+				}
+
+			case 2:
+				localctx = NewAddSubContext(p, NewExprContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, ExprParserRULE_expr)
+				p.SetState(64)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
+					panic(NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
+				}
+				{
+					p.SetState(65)
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == ExprParserADD || _la == ExprParserSUB) {
+						p.GetErrorHandler().RecoverInline(p)
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(66)
+					p.expr(3)
+				}
+
+			}
+
+		}
+		p.SetState(71)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 5, p.GetParserRuleContext())
+	}
+
+	return localctx
+}
+
+// IPrimaryContext is an interface to support dynamic dispatch.
+type IPrimaryContext interface {
+	ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() Parser
+
+	// IsPrimaryContext differentiates from other interfaces.
+	IsPrimaryContext()
+}
+
+type PrimaryContext struct {
+	*BaseParserRuleContext
+	parser Parser
+}
+
+func NewEmptyPrimaryContext() *PrimaryContext {
+	var p = new(PrimaryContext)
+	p.BaseParserRuleContext = NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExprParserRULE_primary
+	return p
+}
+
+func (*PrimaryContext) IsPrimaryContext() {}
+
+func NewPrimaryContext(parser Parser, parent ParserRuleContext, invokingState int) *PrimaryContext {
+	var p = new(PrimaryContext)
+
+	p.BaseParserRuleContext = NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExprParserRULE_primary
+
+	return p
+}
+
+func (s *PrimaryContext) GetParser() Parser { return s.parser }
+
+func (s *PrimaryContext) CopyFrom(ctx *PrimaryContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *PrimaryContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *PrimaryContext) ToStringTree(ruleNames []string, recog Recognizer) string {
+	return TreesStringTree(s, ruleNames, recog)
+}
+
+type IntContext struct {
+	*PrimaryContext
+}
+
+func NewIntContext(parser Parser, ctx ParserRuleContext) *IntContext {
+	var p = new(IntContext)
+
+	p.PrimaryContext = NewEmptyPrimaryContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*PrimaryContext))
+
+	return p
+}
+
+func (s *IntContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *IntContext) INT() TerminalNode {
+	return s.GetToken(ExprParserINT, 0)
+}
+
+type IdContext struct {
+	*PrimaryContext
+}
+
+func NewIdContext(parser Parser, ctx ParserRuleContext) *IdContext {
+	var p = new(IdContext)
+
+	p.PrimaryContext = NewEmptyPrimaryContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*PrimaryContext))
+
+	return p
+}
+
+func (s *IdContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *IdContext) ID() TerminalNode {
+	return s.GetToken(ExprParserID, 0)
+}
+
+type ParensContext struct {
+	*PrimaryContext
+}
+
+func NewParensContext(parser Parser, ctx ParserRuleContext) *ParensContext {
+	var p = new(ParensContext)
+
+	p.PrimaryContext = NewEmptyPrimaryContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*PrimaryContext))
+
+	return p
+}
+
+func (s *ParensContext) GetRuleContext() RuleContext {
+	return s
+}
+
+func (s *ParensContext) Expr() IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprContext)
+}
+
+func (p *ExprParser) Primary() (localctx IPrimaryContext) {
+	localctx = NewPrimaryContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, ExprParserRULE_primary)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer exprParserRecover(p, localctx)
+
+	p.SetState(78)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case ExprParserINT:
+		localctx = NewIntContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(72)
+			p.Match(ExprParserINT)
+		}
+
+	case ExprParserID:
+		localctx = NewIdContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(73)
+			p.Match(ExprParserID)
+		}
+
+	case ExprParserT__1:
+		localctx = NewParensContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(74)
+			p.Match(ExprParserT__1)
+		}
+		{
+			p.SetState(75)
+			p.expr(0)
+		}
+		{
+			p.SetState(76)
+			p.Match(ExprParserT__3)
+		}
+
+	default:
+		panic(NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+func (p *ExprParser) Sempred(localctx RuleContext, ruleIndex, predIndex int) bool {
+	switch ruleIndex {
+	case 5:
+		return p.Expr_Sempred(localctx, predIndex)
+
+	default:
+		panic("No predicate with index: " + fmt.Sprint(ruleIndex))
+	}
+}
+
+func (p *ExprParser) Expr_Sempred(localctx RuleContext, predIndex int) bool {
+	switch predIndex {
+	case 0:
+		return p.Precpred(p.GetParserRuleContext(), 3)
+
+	case 1:
+		return p.Precpred(p.GetParserRuleContext(), 2)
+
+	default:
+		panic("No predicate with index: " + fmt.Sprint(predIndex))
+	}
+}
+
+// newExprParserFor lexes input with ExprLexer and returns a parser over the
+// resulting token stream.
+func newExprParserFor(input string) *ExprParser {
+	lexer := NewExprLexer(NewInputStream(input))
+	stream := NewCommonTokenStream(lexer, TokenDefaultChannel)
+	return NewExprParser(stream)
+}
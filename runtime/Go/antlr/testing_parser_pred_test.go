@@ -0,0 +1,117 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"fmt"
+)
+
+// predParser is a parser for the grammar
+//
+/*
+grammar Pred;
+
+s   :   {$parser.allow}? ID
+    |   ID
+    ;
+*/
+//
+// using the tokens of ExprLexer. Both alternatives match "ID", so the
+// predicate of alternative 1 decides: it is chosen when allow is set.
+
+var predParser_serializedATN = []uint16{
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 19, 11,
+	4, 2, 9, 2, 5, 2, 10, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 10, 2, 2, 2, 3, 2,
+	2, 2, 2, 11, 2, 4, 3, 2, 2, 2, 4, 5, 3, 2, 2, 2, 4, 8, 3, 2, 2, 2, 5,
+	6, 6, 2, 2, 2, 6, 7, 7, 16, 2, 2, 7, 10, 3, 2, 2, 2, 8, 9, 7, 16, 2, 2,
+	9, 10, 3, 2, 2, 2, 10, 3, 3, 2, 2, 2, 3, 4,
+}
+
+var predParser_ruleNames = []string{
+	"s",
+}
+
+type predParser struct {
+	*BaseParser
+
+	allow bool
+}
+
+func newPredParser(input TokenStream) *predParser {
+	this := new(predParser)
+
+	deserializer := NewATNDeserializer(nil)
+	deserializedATN := deserializer.DeserializeFromUInt16(predParser_serializedATN)
+	decisionToDFA := make([]*DFA, len(deserializedATN.DecisionToState))
+	for index, ds := range deserializedATN.DecisionToState {
+		decisionToDFA[index] = NewDFA(ds, index)
+	}
+
+	this.BaseParser = NewBaseParser(input)
+
+	this.Interpreter = NewParserATNSimulator(this, deserializedATN, decisionToDFA, NewPredictionContextCache())
+	this.RuleNames = predParser_ruleNames
+	this.LiteralNames = exprParser_literalNames
+	this.SymbolicNames = exprParser_symbolicNames
+	this.GrammarFileName = "Pred.g4"
+
+	return this
+}
+
+func (p *predParser) S() (localctx ParserRuleContext) {
+	localctx = NewBaseParserRuleContext(p.GetParserRuleContext(), p.GetState())
+	localctx.(*BaseParserRuleContext).RuleIndex = 0
+	p.EnterRule(localctx, 0, 0)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(2)
+	alt := p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 0, p.GetParserRuleContext())
+	p.EnterOuterAlt(localctx, alt)
+	switch alt {
+	case 1:
+		p.SetState(3)
+		if !(p.Sempred(localctx, 0, 0)) {
+			panic(NewFailedPredicateException(p, "$parser.allow", ""))
+		}
+		p.SetState(4)
+		p.Match(ExprLexerID)
+
+	case 2:
+		p.SetState(6)
+		p.Match(ExprLexerID)
+	}
+
+	return localctx
+}
+
+func (p *predParser) Sempred(localctx RuleContext, ruleIndex, predIndex int) bool {
+	if ruleIndex == 0 && predIndex == 0 {
+		return p.allow
+	}
+	panic("No predicate with index: " + fmt.Sprint(ruleIndex) + ", " + fmt.Sprint(predIndex))
+}
+
+// newPredParserFor lexes input with ExprLexer and returns a predParser over
+// the resulting token stream.
+func newPredParserFor(input string, allow bool) *predParser {
+	p := newPredParser(NewCommonTokenStream(NewExprLexer(NewInputStream(input)), TokenDefaultChannel))
+	p.allow = allow
+	return p
+}
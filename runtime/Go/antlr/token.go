@@ -7,6 +7,7 @@ package antlr
 import (
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 type TokenSourceCharStreamPair struct {
@@ -168,10 +169,74 @@ func (c *CommonToken) clone() *CommonToken {
 	return t
 }
 
+// Policies for the text of tokens whose text was set with SetText, see
+// SetTokenTextSource.
+const (
+	// TokenTextSourceOverride makes GetText and GetInputText return the text
+	// set, if any, and the input text otherwise.
+	TokenTextSourceOverride = iota
+
+	// TokenTextSourceInput makes GetText and GetInputText return the input
+	// text of tokens that have an input stream, ignoring the text set.
+	TokenTextSourceInput
+
+	// TokenTextSourceOverrideWithInput makes GetText return the text set, if
+	// any, but GetInputText the input text.
+	TokenTextSourceOverrideWithInput
+)
+
+// tokenTextSource holds the policy set by SetTokenTextSource. It is read
+// by every GetText, so it is accessed atomically.
+var tokenTextSource int32 = TokenTextSourceOverride
+
+// SetTokenTextSource sets which text CommonToken.GetText and GetInputText
+// return for a token whose text was set with SetText, which may differ from
+// the characters of the input it was lexed from. policy is one of the
+// TokenTextSource constants, TokenTextSourceOverride by default. The input
+// text is read from the input stream of the token, so it is only available
+// while the stream still holds the token's characters; tokens that do not
+// span characters of their stream, such as the missing tokens conjured up
+// by error recovery, always return the text set. The policy applies to all
+// tokens of all goroutines and should be set before lexing starts.
+func SetTokenTextSource(policy int) {
+	if policy < TokenTextSourceOverride || policy > TokenTextSourceOverrideWithInput {
+		panic("invalid token text source policy " + strconv.Itoa(policy))
+	}
+	atomic.StoreInt32(&tokenTextSource, int32(policy))
+}
+
+func getTokenTextSource() int {
+	return int(atomic.LoadInt32(&tokenTextSource))
+}
+
 func (c *CommonToken) GetText() string {
+	if getTokenTextSource() == TokenTextSourceInput && c.hasInputText() {
+		return c.inputText()
+	}
 	if c.text != "" {
 		return c.text
 	}
+	return c.inputText()
+}
+
+// GetInputText returns the text of c as it appears in the input under the
+// TokenTextSourceOverrideWithInput and TokenTextSourceInput policies, or
+// GetText under the default policy. A token that does not span characters
+// of an input stream has no input text, so its GetText is returned.
+func (c *CommonToken) GetInputText() string {
+	if getTokenTextSource() == TokenTextSourceOverride || !c.hasInputText() {
+		return c.GetText()
+	}
+	return c.inputText()
+}
+
+// hasInputText reports whether c spans characters of its input stream.
+func (c *CommonToken) hasInputText() bool {
+	input := c.GetInputStream()
+	return input != nil && 0 <= c.start && c.start <= c.stop && c.stop < input.Size()
+}
+
+func (c *CommonToken) inputText() string {
 	input := c.GetInputStream()
 	if input == nil {
 		return ""
@@ -187,12 +252,54 @@ func (c *CommonToken) SetText(text string) {
 	c.text = text
 }
 
+// CommonTokenMaxStringTextLength is the number of characters of token text
+// that CommonToken.String and GetTokenInfo show before cutting it off with
+// "...". A value of 0 or less shows the whole text.
+var CommonTokenMaxStringTextLength = 20
+
 func (c *CommonToken) String() string {
+	return c.toString(strconv.Itoa(c.tokenType))
+}
+
+// GetTokenInfo describes c like String, but gives the token type by its
+// symbolic name, or its literal name if it has no symbolic name, falling
+// back to the number if neither is known:
+//
+//	[@3,4:6='abc',<ID>,1:4]
+func (c *CommonToken) GetTokenInfo(literalNames, symbolicNames []string) string {
+	return c.toString(tokenTypeName(c.tokenType, literalNames, symbolicNames))
+}
+
+// tokenDisplayText shortens txt to CommonTokenMaxStringTextLength characters
+// and escapes line breaks and tabs.
+func tokenDisplayText(txt string) string {
+	if max := CommonTokenMaxStringTextLength; max > 0 {
+		if r := []rune(txt); len(r) > max {
+			txt = string(r[:max]) + "..."
+		}
+	}
+	txt = strings.Replace(txt, "\n", "\\n", -1)
+	txt = strings.Replace(txt, "\r", "\\r", -1)
+	return strings.Replace(txt, "\t", "\\t", -1)
+}
+
+// tokenTypeName returns the symbolic name of tokenType, or its literal name
+// if it has no symbolic name, or the number if neither is known.
+func tokenTypeName(tokenType int, literalNames, symbolicNames []string) string {
+	if tokenType == TokenEOF {
+		return "EOF"
+	} else if tokenType >= 0 && tokenType < len(symbolicNames) && symbolicNames[tokenType] != "" {
+		return symbolicNames[tokenType]
+	} else if tokenType >= 0 && tokenType < len(literalNames) && literalNames[tokenType] != "" {
+		return literalNames[tokenType]
+	}
+	return strconv.Itoa(tokenType)
+}
+
+func (c *CommonToken) toString(typeName string) string {
 	txt := c.GetText()
 	if txt != "" {
-		txt = strings.Replace(txt, "\n", "\\n", -1)
-		txt = strings.Replace(txt, "\r", "\\r", -1)
-		txt = strings.Replace(txt, "\t", "\\t", -1)
+		txt = tokenDisplayText(txt)
 	} else {
 		txt = "<no text>"
 	}
@@ -205,6 +312,6 @@ func (c *CommonToken) String() string {
 	}
 
 	return "[@" + strconv.Itoa(c.tokenIndex) + "," + strconv.Itoa(c.start) + ":" + strconv.Itoa(c.stop) + "='" +
-		txt + "',<" + strconv.Itoa(c.tokenType) + ">" +
+		txt + "',<" + typeName + ">" +
 		ch + "," + strconv.Itoa(c.line) + ":" + strconv.Itoa(c.column) + "]"
 }
@@ -15,3 +15,70 @@ type TokenSource interface {
 	setTokenFactory(factory TokenFactory)
 	GetTokenFactory() TokenFactory
 }
+
+// BoundedTokenSource is a TokenSource that stops after a maximum number of
+// tokens, to guard against unbounded input. See NewBoundedTokenSource.
+type BoundedTokenSource struct {
+	TokenSource
+
+	// OnLimitExceeded, if set, is called once with the first token beyond
+	// the limit, which is dropped.
+	OnLimitExceeded func(t Token)
+
+	maxTokens int
+	count     int
+	exceeded  bool
+}
+
+// NewBoundedTokenSource returns a TokenSource that passes on the tokens of
+// inner until it has produced maxTokens tokens other than EOF, and EOF
+// after that. Once the limit is reached inner is asked for at most one more
+// token, to tell whether the input really ends there, so an oversized input
+// is never lexed further.
+//
+// As NewCommonTokenStream takes a Lexer, install the bounded source with
+// SetTokenSource:
+//
+//	stream := NewCommonTokenStream(nil, TokenDefaultChannel)
+//	stream.SetTokenSource(NewBoundedTokenSource(lexer, 100000))
+func NewBoundedTokenSource(inner TokenSource, maxTokens int) *BoundedTokenSource {
+	return &BoundedTokenSource{TokenSource: inner, maxTokens: maxTokens}
+}
+
+func (b *BoundedTokenSource) NextToken() Token {
+	if b.exceeded {
+		return b.eof(nil)
+	}
+	t := b.TokenSource.NextToken()
+	if t.GetTokenType() == TokenEOF {
+		return t
+	}
+	if b.count >= b.maxTokens {
+		b.exceeded = true
+		if b.OnLimitExceeded != nil {
+			b.OnLimitExceeded(t)
+		}
+		return b.eof(t)
+	}
+	b.count++
+	return t
+}
+
+// eof creates an EOF token at the start of dropped, or at the current
+// position of the input if dropped is nil.
+func (b *BoundedTokenSource) eof(dropped Token) Token {
+	start, line, column := -1, b.GetLine(), b.GetCharPositionInLine()
+	if input := b.GetInputStream(); input != nil {
+		start = input.Index()
+	}
+	if dropped != nil {
+		start, line, column = dropped.GetStart(), dropped.GetLine(), dropped.GetColumn()
+	}
+	pair := &TokenSourceCharStreamPair{b, b.GetInputStream()}
+	return b.GetTokenFactory().Create(pair, TokenEOF, "<EOF>", TokenDefaultChannel, start, start-1, line, column)
+}
+
+// Exceeded reports whether the input had more tokens than the limit.
+func (b *BoundedTokenSource) Exceeded() bool {
+	return b.exceeded
+}
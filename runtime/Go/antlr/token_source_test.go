@@ -0,0 +1,65 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func newBoundedTokenStream(source *BoundedTokenSource) *CommonTokenStream {
+	ts := NewCommonTokenStream(nil, TokenDefaultChannel)
+	ts.SetTokenSource(source)
+	return ts
+}
+
+func TestBoundedTokenSource(t *testing.T) {
+	assert := assertNew(t)
+	input := NewInputStream("a b c d e f")
+	source := NewBoundedTokenSource(NewExprLexer(input), 3)
+	var dropped []string
+	source.OnLimitExceeded = func(t Token) {
+		dropped = append(dropped, t.GetText())
+	}
+
+	ts := newBoundedTokenStream(source)
+	tokens := ts.GetAllTokens()
+	assert.Equal([]int{ExprLexerID, ExprLexerID, ExprLexerID, TokenEOF}, lexerTestTokenTypes(tokens))
+	assert.Equal(6, tokens[3].GetStart())
+	assert.Equal(1, tokens[3].GetLine())
+	assert.Equal(6, tokens[3].GetColumn())
+	assert.Equal(true, source.Exceeded())
+	assert.Equal([]string{"d"}, dropped)
+
+	// lexing stopped right after the first dropped token
+	assert.Equal(7, input.Index())
+	assert.Equal(TokenEOF, source.NextToken().GetTokenType())
+	assert.Equal([]string{"d"}, dropped)
+}
+
+func TestBoundedTokenSourceWithinLimit(t *testing.T) {
+	assert := assertNew(t)
+	source := NewBoundedTokenSource(NewExprLexer(NewInputStream("a b c")), 3)
+	source.OnLimitExceeded = func(t Token) {
+		assert.Fail("limit not exceeded")
+	}
+
+	tokens := newBoundedTokenStream(source).GetAllTokens()
+	assert.Equal([]int{ExprLexerID, ExprLexerID, ExprLexerID, TokenEOF}, lexerTestTokenTypes(tokens))
+	assert.Equal(false, source.Exceeded())
+}
+
+func TestBoundedTokenSourceParse(t *testing.T) {
+	assert := assertNew(t)
+	source := NewBoundedTokenSource(NewExprLexer(NewInputStream("def f(x) { x = 1; }")), 8)
+	p := NewExprParser(newBoundedTokenStream(source))
+	collector := NewCollectingErrorListener()
+	p.RemoveErrorListeners()
+	p.AddErrorListener(collector)
+	p.Prog()
+
+	assert.Equal(true, source.Exceeded())
+	assert.Equal(1, len(collector.Errors()))
+	assert.Equal("<EOF>", collector.Errors()[0].OffendingText)
+}
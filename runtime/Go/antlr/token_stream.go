@@ -4,6 +4,13 @@
 
 package antlr
 
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
 type TokenStream interface {
 	IntStream
 
@@ -18,3 +25,108 @@ type TokenStream interface {
 	GetTextFromRuleContext(RuleContext) string
 	GetTextFromTokens(Token, Token) string
 }
+
+// ValidateTokenStream checks that ts is well formed before it is handed to a
+// parser: token indexes run from 0 without gaps, no token is on a negative
+// channel, token start positions and lines never decrease, and the stream
+// ends with its only EOF token. A CommonTokenStream is filled first; other
+// streams are checked as far as they are buffered. The first violation found
+// is returned as an error.
+func ValidateTokenStream(ts TokenStream) error {
+	if c, ok := ts.(*CommonTokenStream); ok {
+		c.Fill()
+	}
+
+	n := ts.Size()
+	if n == 0 {
+		return errors.New("token stream is empty, expected a final EOF token")
+	}
+
+	var prev Token
+	for i := 0; i < n; i++ {
+		t := ts.Get(i)
+		if t.GetTokenIndex() != i {
+			return fmt.Errorf("token %v at position %d has index %d", t, i, t.GetTokenIndex())
+		}
+		if t.GetChannel() < 0 {
+			return fmt.Errorf("token %v has negative channel %d", t, t.GetChannel())
+		}
+		if t.GetTokenType() == TokenEOF && i < n-1 {
+			return fmt.Errorf("token %v is EOF but %d more tokens follow it", t, n-1-i)
+		}
+		if prev != nil {
+			if t.GetStart() < prev.GetStart() {
+				return fmt.Errorf("token %v starts at %d, before the previous token %v", t, t.GetStart(), prev)
+			}
+			if t.GetLine() < prev.GetLine() {
+				return fmt.Errorf("token %v is on line %d, before the previous token %v", t, t.GetLine(), prev)
+			}
+		}
+		prev = t
+	}
+
+	if prev.GetTokenType() != TokenEOF {
+		return fmt.Errorf("last token %v is not EOF", prev)
+	}
+
+	return nil
+}
+
+// TokenSummary renders the next max tokens of ts, from LT(1) on, as a single
+// line for logging, such as
+//
+//	ID('x') '='('=') INT('1') ...
+//
+// The tokens are named as in CommonToken.GetTokenInfo, using the names of
+// recog if it is not nil, and their text is escaped and shortened the same
+// way. The summary ends with "..." if more tokens follow, and with EOF if
+// the stream ends. A max of 0 or less renders all tokens up to EOF. The
+// position of ts is not changed.
+func TokenSummary(ts TokenStream, recog Recognizer, max int) string {
+	var literalNames, symbolicNames []string
+	if recog != nil {
+		literalNames, symbolicNames = recog.GetLiteralNames(), recog.GetSymbolicNames()
+	}
+	parts := make([]string, 0)
+	for k := 1; max <= 0 || k <= max; k++ {
+		t := ts.LT(k)
+		if t == nil {
+			break
+		}
+		if t.GetTokenType() == TokenEOF {
+			parts = append(parts, "EOF")
+			return strings.Join(parts, " ")
+		}
+		parts = append(parts, tokenTypeName(t.GetTokenType(), literalNames, symbolicNames)+"('"+tokenDisplayText(t.GetText())+"')")
+	}
+	if max > 0 {
+		if t := ts.LT(max + 1); t != nil && t.GetTokenType() != TokenEOF {
+			parts = append(parts, "...")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// TokenStreamFingerprint returns a 64-bit FNV-1a hash of the sequence of
+// token types of ts, and of their text if includeText is set, suitable as
+// the key of a cache of parse results. Positions do not contribute, so
+// inputs only differing in whitespace skipped by the lexer fingerprint
+// equally, and so do inputs only differing in identifiers or literals when
+// includeText is not set. A CommonTokenStream is filled first and only the
+// tokens on its channel are hashed, since the parser sees no others; other
+// streams are hashed as far as they are buffered. The position of ts is not
+// changed.
+func TokenStreamFingerprint(ts TokenStream, includeText bool) uint64 {
+	h := fnv.New64a()
+	c, isCommon := ts.(*CommonTokenStream)
+	for _, t := range tokenStreamTokens(ts) {
+		if isCommon && !c.isOnChannel(t, c.channel) {
+			continue
+		}
+		writeTreeHashInt(h, t.GetTokenType())
+		if includeText {
+			writeTreeHashString(h, t.GetText())
+		}
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"fmt"
+)
+
+// Kinds of TokenEdit.
+const (
+	// TokenEditInsert inserts the tokens B of the new stream at A.Start of
+	// the old stream; A is empty.
+	TokenEditInsert = iota
+
+	// TokenEditDelete deletes the tokens A of the old stream; B is empty and
+	// starts where the deleted tokens would have been in the new stream.
+	TokenEditDelete
+
+	// TokenEditReplace replaces the tokens A of the old stream by the tokens
+	// B of the new stream.
+	TokenEditReplace
+)
+
+// TokenEdit is one change needed to turn a token stream into another one.
+// A holds the positions of the affected tokens in the old stream, B in the
+// new stream; both are half open intervals as created by NewInterval.
+type TokenEdit struct {
+	Kind int
+	A    *Interval
+	B    *Interval
+}
+
+func (e TokenEdit) String() string {
+	kind := [...]string{"insert", "delete", "replace"}[e.Kind]
+	return fmt.Sprintf("%s %d..%d -> %d..%d", kind, e.A.Start, e.A.Stop, e.B.Start, e.B.Stop)
+}
+
+// TokenStreamDiff returns the edits turning the tokens of a into the tokens
+// of b, in increasing position order, or an empty slice if both hold the same
+// tokens. Tokens are equal if they have the same type and text; positions,
+// channels and indexes are not compared. The edits follow a longest common
+// subsequence of the two streams, so only the tokens outside of it are
+// touched, and a deletion directly followed by an insertion is reported as a
+// single replacement. A CommonTokenStream is filled first; other streams are
+// compared as far as they are buffered.
+//
+// The comparison takes time and memory proportional to the product of the
+// stream sizes, which suits the change of a file being edited but not of
+// unrelated large inputs.
+func TokenStreamDiff(a, b TokenStream) []TokenEdit {
+	as, bs := tokenStreamTokens(a), tokenStreamTokens(b)
+	n, m := len(as), len(bs)
+
+	// lcs[i][j] is the length of the longest common subsequence of as[i:]
+	// and bs[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if tokensEqual(as[i], bs[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = intMax(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]TokenEdit, 0)
+	i, j := 0, 0
+	for i < n || j < m {
+		if i < n && j < m && tokensEqual(as[i], bs[j]) {
+			i++
+			j++
+			continue
+		}
+		// collect the run of tokens outside of the common subsequence
+		i0, j0 := i, j
+		for i < n || j < m {
+			if i < n && j < m && tokensEqual(as[i], bs[j]) && lcs[i][j] == lcs[i+1][j+1]+1 {
+				break
+			}
+			if j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]) {
+				i++
+			} else {
+				j++
+			}
+		}
+		kind := TokenEditReplace
+		if i == i0 {
+			kind = TokenEditInsert
+		} else if j == j0 {
+			kind = TokenEditDelete
+		}
+		edits = append(edits, TokenEdit{Kind: kind, A: NewInterval(i0, i), B: NewInterval(j0, j)})
+	}
+	return edits
+}
+
+func tokenStreamTokens(ts TokenStream) []Token {
+	if c, ok := ts.(*CommonTokenStream); ok {
+		c.Fill()
+	}
+	tokens := make([]Token, ts.Size())
+	for i := range tokens {
+		tokens[i] = ts.Get(i)
+	}
+	return tokens
+}
+
+func tokensEqual(a, b Token) bool {
+	return a.GetTokenType() == b.GetTokenType() && a.GetText() == b.GetText()
+}
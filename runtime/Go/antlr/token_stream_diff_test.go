@@ -0,0 +1,30 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestTokenStreamDiff(t *testing.T) {
+	assert := assertNew(t)
+
+	assert.Equal([]TokenEdit{}, TokenStreamDiff(newExprTokenStream("x = 1;"), newExprTokenStream("x  =  1 ;")))
+
+	// one token inserted
+	edits := TokenStreamDiff(newExprTokenStream("f(x, y);"), newExprTokenStream("f(x, -y);"))
+	assert.Equal(1, len(edits))
+	assert.Equal("insert 4..4 -> 4..5", edits[0].String())
+
+	// and the reverse
+	edits = TokenStreamDiff(newExprTokenStream("f(x, -y);"), newExprTokenStream("f(x, y);"))
+	assert.Equal(1, len(edits))
+	assert.Equal("delete 4..5 -> 4..4", edits[0].String())
+
+	edits = TokenStreamDiff(newExprTokenStream("x = 1 + 2;"), newExprTokenStream("x = 7 + 2; y;"))
+	assert.Equal(2, len(edits))
+	assert.Equal("replace 2..3 -> 2..3", edits[0].String())
+	assert.Equal("insert 6..6 -> 6..8", edits[1].String())
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func newTokenStreamTestStream() *CommonTokenStream {
+	stream := NewCommonTokenStream(NewExprLexer(NewInputStream("def f(x) {\n  x = 1;\n}")), TokenDefaultChannel)
+	stream.Fill()
+	return stream
+}
+
+func TestValidateTokenStream(t *testing.T) {
+	assert := assertNew(t)
+
+	assert.Nil(ValidateTokenStream(NewCommonTokenStream(NewExprLexer(NewInputStream("def f(x) { x; }")), TokenDefaultChannel)))
+	assert.Nil(ValidateTokenStream(NewCommonTokenStream(NewExprLexer(NewInputStream("")), TokenDefaultChannel)))
+}
+
+func TestValidateTokenStreamIndex(t *testing.T) {
+	assert := assertNew(t)
+	stream := newTokenStreamTestStream()
+	stream.Get(3).SetTokenIndex(4)
+
+	err := ValidateTokenStream(stream)
+	assert.NotNil(err)
+	assert.Equal("token [@4,6:6='x',<14>,1:6] at position 3 has index 4", err.Error())
+}
+
+func TestValidateTokenStreamChannel(t *testing.T) {
+	assert := assertNew(t)
+	stream := newTokenStreamTestStream()
+	stream.Get(2).(*CommonToken).channel = -1
+
+	err := ValidateTokenStream(stream)
+	assert.NotNil(err)
+	assert.Equal("token [@2,5:5='(',<2>,1:5] has negative channel -1", err.Error())
+}
+
+func TestValidateTokenStreamPositions(t *testing.T) {
+	assert := assertNew(t)
+	stream := newTokenStreamTestStream()
+	stream.Get(5).(*CommonToken).start = 2
+
+	err := ValidateTokenStream(stream)
+	assert.NotNil(err)
+	assert.Equal("token [@5,2:9='f f(x) {',<5>,1:9] starts at 2, before the previous token [@4,7:7=')',<4>,1:7]", err.Error())
+
+	stream = newTokenStreamTestStream()
+	stream.Get(7).(*CommonToken).line = 1
+
+	err = ValidateTokenStream(stream)
+	assert.NotNil(err)
+	assert.Equal("token [@7,15:15='=',<8>,1:4] is on line 1, before the previous token [@6,13:13='x',<14>,2:2]", err.Error())
+}
+
+func TestValidateTokenStreamEOF(t *testing.T) {
+	assert := assertNew(t)
+	stream := newTokenStreamTestStream()
+	stream.tokens = stream.tokens[:len(stream.tokens)-1]
+
+	err := ValidateTokenStream(stream)
+	assert.NotNil(err)
+	assert.Equal("last token [@10,20:20='}',<6>,3:0] is not EOF", err.Error())
+
+	stream = newTokenStreamTestStream()
+	stream.Get(9).(*CommonToken).tokenType = TokenEOF
+
+	err = ValidateTokenStream(stream)
+	assert.NotNil(err)
+	assert.Equal("token [@9,18:18=';',<-1>,2:7] is EOF but 2 more tokens follow it", err.Error())
+}
+
+func TestValidateTokenStreamEmpty(t *testing.T) {
+	assert := assertNew(t)
+	stream := newTokenStreamTestStream()
+	stream.tokens = stream.tokens[:0]
+
+	assert.NotNil(ValidateTokenStream(stream))
+}
+
+func TestTokenSummary(t *testing.T) {
+	assert := assertNew(t)
+	stream := newTokenStreamTestStream()
+	stream.Seek(0)
+	p := NewExprParser(stream)
+
+	assert.Equal("'def'('def') ID('f') '('('(') ...", TokenSummary(stream, p, 3))
+	assert.Equal("'def'('def') ID('f') '('('(') ID('x') ')'(')') '{'('{') ID('x') '='('=') INT('1') ';'(';') '}'('}') EOF",
+		TokenSummary(stream, p, 0))
+	assert.Equal(TokenSummary(stream, p, 0), TokenSummary(stream, p, 12))
+	assert.Equal(TokenSummary(stream, p, 0), TokenSummary(stream, p, 100))
+	assert.Equal("1('def') 14('f') ...", TokenSummary(stream, nil, 2))
+	assert.Equal(0, stream.Index())
+
+	// the last token before EOF needs no ellipsis
+	stream.Seek(10)
+	assert.Equal("'}'('}')", TokenSummary(stream, p, 1))
+	assert.Equal("'}'('}') EOF", TokenSummary(stream, p, 2))
+	stream.Seek(11)
+	assert.Equal("EOF", TokenSummary(stream, p, 1))
+
+	// without a limit only positive offsets are looked at
+	stream.Seek(10)
+	strict := &tokenStreamTestPositiveLTStream{stream}
+	assert.Equal("'}'('}')", TokenSummary(strict, p, 0))
+	assert.Equal("'}'('}')", TokenSummary(strict, p, -1))
+}
+
+// tokenStreamTestPositiveLTStream returns nil instead of EOF and panics
+// when LT is called with an offset that is not positive.
+type tokenStreamTestPositiveLTStream struct {
+	TokenStream
+}
+
+func (s *tokenStreamTestPositiveLTStream) LT(k int) Token {
+	if k <= 0 {
+		panic("LT called with a non-positive offset")
+	}
+	if t := s.TokenStream.LT(k); t.GetTokenType() != TokenEOF {
+		return t
+	}
+	return nil
+}
+
+func TestTokenStreamFingerprint(t *testing.T) {
+	assert := assertNew(t)
+	fingerprint := func(input string, includeText bool) uint64 {
+		return TokenStreamFingerprint(NewCommonTokenStream(newExprCommentLexer(input), TokenDefaultChannel), includeText)
+	}
+
+	a := fingerprint("x = 1;\nreturn x;", false)
+	assert.Equal(a, fingerprint("x=1;   return\tx ;", false))
+	assert.Equal(a, fingerprint("x = 1; // set\nreturn x;", false))
+	assert.Equal(a, fingerprint("y = 2; return z;", false))
+	assert.Equal(false, a == fingerprint("x = 1; return;", false))
+
+	b := fingerprint("x = 1;\nreturn x;", true)
+	assert.Equal(b, fingerprint("x=1;   return\tx ;", true))
+	assert.Equal(false, b == fingerprint("y = 2; return z;", true))
+	assert.Equal(false, a == b)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestCommonTokenStringTruncation(t *testing.T) {
+	assert := assertNew(t)
+	ts := NewCommonTokenStream(NewExprLexer(NewInputStream("x = abcdefghijklmnopqrstuvwxyz;")), TokenDefaultChannel)
+	ts.Fill()
+
+	assert.Equal("[@0,0:0='x',<14>,1:0]", ts.Get(0).(*CommonToken).String())
+	assert.Equal("[@2,4:29='abcdefghijklmnopqrst...',<14>,1:4]", ts.Get(2).(*CommonToken).String())
+
+	defer func(max int) { CommonTokenMaxStringTextLength = max }(CommonTokenMaxStringTextLength)
+	CommonTokenMaxStringTextLength = 0
+	assert.Equal("[@2,4:29='abcdefghijklmnopqrstuvwxyz',<14>,1:4]", ts.Get(2).(*CommonToken).String())
+	CommonTokenMaxStringTextLength = 3
+	assert.Equal("[@2,4:29='abc...',<14>,1:4]", ts.Get(2).(*CommonToken).String())
+}
+
+func TestCommonTokenGetTokenInfo(t *testing.T) {
+	assert := assertNew(t)
+	ts := NewCommonTokenStream(NewExprLexer(NewInputStream("x = (1);")), TokenDefaultChannel)
+	ts.Fill()
+	info := func(i int) string {
+		return ts.Get(i).(*CommonToken).GetTokenInfo(exprParser_literalNames, exprParser_symbolicNames)
+	}
+
+	assert.Equal("[@0,0:0='x',<ID>,1:0]", info(0))
+	assert.Equal("[@1,2:2='=',<'='>,1:2]", info(1))
+	assert.Equal("[@3,5:5='1',<INT>,1:5]", info(3))
+	assert.Equal("[@6,8:7='<EOF>',<EOF>,1:8]", info(6))
+	assert.Equal("[@0,0:0='x',<14>,1:0]", ts.Get(0).(*CommonToken).GetTokenInfo(nil, nil))
+
+	tok := ts.Get(0).(*CommonToken).clone()
+	tok.SetText("a\tb\nc\r")
+	tok.channel = 2
+	assert.Equal("[@0,0:0='a\\tb\\nc\\r',<ID>,channel=2,1:0]", tok.GetTokenInfo(exprParser_literalNames, exprParser_symbolicNames))
+}
+
+func TestSetTokenTextSource(t *testing.T) {
+	assert := assertNew(t)
+	ts := NewCommonTokenStream(NewExprLexer(NewInputStream("x = 1;")), TokenDefaultChannel)
+	ts.Fill()
+	x := ts.Get(0).(*CommonToken)
+	x.SetText("renamed")
+	one := ts.Get(2).(*CommonToken)
+	detached := NewCommonToken(&TokenSourceCharStreamPair{}, ExprLexerID, TokenDefaultChannel, 0, 0)
+	detached.SetText("y")
+
+	defer SetTokenTextSource(TokenTextSourceOverride)
+
+	assert.Equal("renamed", x.GetText())
+	assert.Equal("renamed", x.GetInputText())
+	assert.Equal("1", one.GetInputText())
+
+	SetTokenTextSource(TokenTextSourceInput)
+	assert.Equal("x", x.GetText())
+	assert.Equal("x", x.GetInputText())
+	assert.Equal("1", one.GetText())
+	assert.Equal("y", detached.GetText())
+	assert.Equal("[@0,0:0='x',<14>,1:0]", x.String())
+
+	SetTokenTextSource(TokenTextSourceOverrideWithInput)
+	assert.Equal("renamed", x.GetText())
+	assert.Equal("x", x.GetInputText())
+	assert.Equal("1", one.GetText())
+	assert.Equal("1", one.GetInputText())
+	assert.Equal("y", detached.GetInputText())
+	assert.Equal("<EOF>", ts.Get(4).(*CommonToken).GetInputText())
+
+	assert.Panics(func() { SetTokenTextSource(3) })
+	assert.Equal("x", x.GetInputText())
+}
+
+func TestSetTokenTextSourceMissingToken(t *testing.T) {
+	assert := assertNew(t)
+	defer SetTokenTextSource(TokenTextSourceOverride)
+
+	for _, policy := range []int{TokenTextSourceOverride, TokenTextSourceInput, TokenTextSourceOverrideWithInput} {
+		SetTokenTextSource(policy)
+		p := newExprParserFor("def f(x) { x = 1 }")
+		p.RemoveErrorListeners()
+		tree := p.Prog()
+
+		// error recovery conjures up the missing ';', which spans no input
+		missing := TreesFindAllTokenNodes(tree, ExprParserT__6)
+		assert.Equal(1, len(missing))
+		tok := missing[0].(TerminalNode).GetSymbol().(*CommonToken)
+		assert.Equal(-1, tok.GetStart())
+		assert.Equal("<missing ';'>", tok.GetText())
+		assert.Equal("<missing ';'>", tok.GetInputText())
+		assert.Equal("(prog (func_ def f ( (arg x) ) (body { (stat x = (expr (primary 1)) <missing ';'>) })))", tree.ToStringTree(nil, p))
+	}
+}
@@ -4,6 +4,10 @@
 
 package antlr
 
+import (
+	"fmt"
+)
+
 // The basic notion of a tree has a parent, a payload, and a list of children.
 //  It is the most abstract interface for all the trees used by ANTLR.
 ///
@@ -97,6 +101,14 @@ type ParseTreeListener interface {
 	ExitEveryRule(ctx ParserRuleContext)
 }
 
+// SkippingListener is implemented by listeners that may skip whole
+// subtrees. The walker calls ShouldDescend after entering a rule node; if it
+// returns false the children of the node are not walked, but the node is
+// still exited.
+type SkippingListener interface {
+	ShouldDescend(ctx ParserRuleContext) bool
+}
+
 type BaseParseTreeListener struct{}
 
 var _ ParseTreeListener = &BaseParseTreeListener{}
@@ -171,6 +183,22 @@ func (t *TerminalNodeImpl) GetText() string {
 	return t.symbol.GetText()
 }
 
+// DecodedText returns the value of a literal token, such as a string or
+// character literal, by applying unescaper to the raw text of its symbol,
+// quotes included. A nil unescaper returns the raw text. An error of
+// unescaper is returned wrapped with the position of the token.
+func (t *TerminalNodeImpl) DecodedText(unescaper func(string) (string, error)) (string, error) {
+	text := t.GetText()
+	if unescaper == nil {
+		return text, nil
+	}
+	decoded, err := unescaper(text)
+	if err != nil {
+		return "", fmt.Errorf("line %d:%d: %w", t.symbol.GetLine(), t.symbol.GetColumn(), err)
+	}
+	return decoded, nil
+}
+
 func (t *TerminalNodeImpl) String() string {
 	if t.symbol.GetTokenType() == TokenEOF {
 		return "<EOF>"
@@ -218,6 +246,8 @@ func NewParseTreeWalker() *ParseTreeWalker {
 // with depth-first search. On each node, EnterRule is called before
 // recursively walking down into child nodes, then
 // ExitRule is called after the recursive call to wind up.
+// Child nodes are skipped if the listener is a SkippingListener whose
+// ShouldDescend returns false for the node.
 func (p *ParseTreeWalker) Walk(listener ParseTreeListener, t Tree) {
 	switch tt := t.(type) {
 	case ErrorNode:
@@ -226,6 +256,10 @@ func (p *ParseTreeWalker) Walk(listener ParseTreeListener, t Tree) {
 		listener.VisitTerminal(tt)
 	default:
 		p.EnterRule(listener, t.(RuleNode))
+		if s, ok := listener.(SkippingListener); ok && !s.ShouldDescend(t.(RuleNode).GetRuleContext().(ParserRuleContext)) {
+			p.ExitRule(listener, t.(RuleNode))
+			return
+		}
 		for i := 0; i < t.GetChildCount(); i++ {
 			child := t.GetChild(i)
 			p.Walk(listener, child)
@@ -0,0 +1,116 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+)
+
+// Node kinds written to the hash ahead of each node.
+const (
+	treeHashRule byte = iota + 1
+	treeHashTerminal
+	treeHashError
+)
+
+// HashTree returns a 64-bit FNV-1a hash of the structure of t: the rule of
+// every rule node, the token type and text of every terminal, and how they
+// nest. Error nodes hash differently from terminals holding the same
+// token. The hash only depends on the tree content, so structurally
+// identical trees hash equal, in any process. If ruleNames is not nil, rule
+// nodes are hashed by name rather than index, which keeps the hash stable
+// when rules are added to the grammar.
+func HashTree(t Tree, ruleNames []string) uint64 {
+	return hashTree(t, ruleNames, true)
+}
+
+// HashTreeShape is like HashTree but ignores the text of the tokens, so
+// trees that only differ in, say, their identifiers hash equal.
+func HashTreeShape(t Tree, ruleNames []string) uint64 {
+	return hashTree(t, ruleNames, false)
+}
+
+func hashTree(t Tree, ruleNames []string, includeText bool) uint64 {
+	h := fnv.New64a()
+	writeTreeHash(h, t, ruleNames, includeText)
+	return h.Sum64()
+}
+
+func writeTreeHash(h hash.Hash64, t Tree, ruleNames []string, includeText bool) {
+	switch n := t.(type) {
+	case ErrorNode:
+		writeTreeHashToken(h, treeHashError, n.GetSymbol(), includeText)
+	case TerminalNode:
+		writeTreeHashToken(h, treeHashTerminal, n.GetSymbol(), includeText)
+	case RuleNode:
+		h.Write([]byte{treeHashRule})
+		ruleIndex := n.GetRuleContext().GetRuleIndex()
+		if ruleNames != nil && ruleIndex >= 0 && ruleIndex < len(ruleNames) {
+			writeTreeHashString(h, ruleNames[ruleIndex])
+		} else {
+			writeTreeHashInt(h, ruleIndex)
+		}
+		writeTreeHashInt(h, t.GetChildCount())
+		for i := 0; i < t.GetChildCount(); i++ {
+			writeTreeHash(h, t.GetChild(i), ruleNames, includeText)
+		}
+	}
+}
+
+func writeTreeHashToken(h hash.Hash64, kind byte, token Token, includeText bool) {
+	h.Write([]byte{kind})
+	writeTreeHashInt(h, token.GetTokenType())
+	if includeText {
+		writeTreeHashString(h, token.GetText())
+	}
+}
+
+func writeTreeHashInt(h hash.Hash64, i int) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(int64(i)))
+	h.Write(b[:])
+}
+
+func writeTreeHashString(h hash.Hash64, s string) {
+	writeTreeHashInt(h, len(s))
+	h.Write([]byte(s))
+}
+
+// TreesComputeHashes hashes every node of t in one post-order pass and
+// returns the hashes keyed by node, as reached through GetChild from t. The
+// hash of a node combines its kind, its rule or its token type and text, and
+// the hashes of its children, so structurally identical subtrees hash
+// equal, like with HashTree, and nodes whose hashes differ cannot be equal.
+// The map must be recomputed when the tree changes.
+func TreesComputeHashes(t ParseTree) map[ParseTree]uint64 {
+	hashes := make(map[ParseTree]uint64)
+	treesComputeHash(t, hashes)
+	return hashes
+}
+
+func treesComputeHash(t ParseTree, hashes map[ParseTree]uint64) uint64 {
+	h := fnv.New64a()
+	switch n := t.(type) {
+	case ErrorNode:
+		writeTreeHashToken(h, treeHashError, n.GetSymbol(), true)
+	case TerminalNode:
+		writeTreeHashToken(h, treeHashTerminal, n.GetSymbol(), true)
+	case RuleNode:
+		h.Write([]byte{treeHashRule})
+		writeTreeHashInt(h, n.GetRuleContext().GetRuleIndex())
+		writeTreeHashInt(h, t.GetChildCount())
+		for i := 0; i < t.GetChildCount(); i++ {
+			sum := treesComputeHash(t.GetChild(i).(ParseTree), hashes)
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], sum)
+			h.Write(b[:])
+		}
+	}
+	sum := h.Sum64()
+	hashes[t] = sum
+	return sum
+}
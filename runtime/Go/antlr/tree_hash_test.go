@@ -0,0 +1,85 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestHashTree(t *testing.T) {
+	assert := assertNew(t)
+	input := "def f(x) { y = x*2; return y; }"
+	a := newExprParserFor(input).Prog()
+	b := newExprParserFor("def   f(x) {\n  y = x * 2;\n  return y;\n}").Prog()
+
+	assert.Equal(HashTree(a, nil), HashTree(b, nil))
+	assert.Equal(HashTree(a, exprParser_ruleNames), HashTree(b, exprParser_ruleNames))
+	assert.Equal(false, HashTree(a, nil) == HashTree(a, exprParser_ruleNames))
+	// the hash is stable across runs
+	assert.Equal(uint64(0x86869ff054e08269), HashTree(a, nil))
+
+	different := []string{
+		"def f(x) { y = x+2; return y; }",
+		"def f(x) { y = (x*2); return y; }",
+		"def f(x) { return y; }",
+		"def f(x, y) { y = x*2; return y; }",
+		"def f(x) { y = x*2; return y; }\ndef g(x) { x; }",
+	}
+	for _, input := range different {
+		assert.Equal(false, HashTree(a, nil) == HashTree(newExprParserFor(input).Prog(), nil))
+	}
+}
+
+func TestHashTreeShape(t *testing.T) {
+	assert := assertNew(t)
+	a := newExprParserFor("def f(x) { y = x*2; return y; }").Prog()
+	b := newExprParserFor("def g(a) { b = a*3; return b; }").Prog()
+
+	assert.Equal(false, HashTree(a, nil) == HashTree(b, nil))
+	assert.Equal(HashTreeShape(a, nil), HashTreeShape(b, nil))
+	assert.Equal(false, HashTreeShape(a, nil) == HashTreeShape(newExprParserFor("def g(a) { b = a/3; return b; }").Prog(), nil))
+}
+
+func TestHashTreeErrorNodes(t *testing.T) {
+	assert := assertNew(t)
+	parse := func(input string) ParserRuleContext {
+		p := newExprParserFor(input)
+		p.RemoveErrorListeners()
+		return p.Prog()
+	}
+	a := parse("def f(x) { x = 1 2; }")
+	assert.Equal(HashTree(a, nil), HashTree(parse("def f(x) { x = 1 2; }"), nil))
+	assert.Equal(false, HashTree(a, nil) == HashTree(parse("def f(x) { x = 1 3; }"), nil))
+	assert.Equal(HashTreeShape(a, nil), HashTreeShape(parse("def f(x) { x = 1 3; }"), nil))
+
+	// a terminal and an error node for the same token differ
+	token := newExprParserFor("x").GetTokenStream().LT(1)
+	assert.Equal(false, HashTree(NewTerminalNodeImpl(token), nil) == HashTree(NewErrorNodeImpl(token), nil))
+}
+
+func TestTreesComputeHashes(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(x) { y = x*2; return y; y = x*2; }\ndef g(x) { y = x*2; }").Prog()
+	hashes := TreesComputeHashes(tree)
+
+	assert.Equal(len(TreesDescendants(tree)), len(hashes))
+	stats := TreesfindAllRuleNodes(tree, ExprParserRULE_stat)
+	assert.Equal(4, len(stats))
+	// the "y = x*2;" statements are identical, "return y;" is not
+	assert.Equal(hashes[stats[0]], hashes[stats[2]])
+	assert.Equal(hashes[stats[0]], hashes[stats[3]])
+	assert.Equal(false, hashes[stats[0]] == hashes[stats[1]])
+
+	// and so are the hashes of a separately parsed tree
+	otherTree := newExprParserFor("def h(a) { y = x*2; }").Prog()
+	other := TreesComputeHashes(otherTree)
+	otherStats := TreesfindAllRuleNodes(otherTree, ExprParserRULE_stat)
+	assert.Equal(hashes[stats[0]], other[otherStats[0]])
+
+	terminals := TreesFindAllTokenNodes(tree, ExprParserID)
+	assert.Equal(hashes[terminals[1]], hashes[terminals[6]]) // x and x
+	assert.Equal(false, hashes[terminals[0]] == hashes[terminals[1]])
+	assert.Equal(false, hashes[tree] == hashes[tree.GetChild(0).(ParseTree)])
+}
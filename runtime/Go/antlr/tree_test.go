@@ -0,0 +1,86 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// treeTestSkippingListener records the walk and does not descend into
+// stat nodes.
+type treeTestSkippingListener struct {
+	*BaseParseTreeListener
+
+	events []string
+}
+
+func (l *treeTestSkippingListener) EnterEveryRule(ctx ParserRuleContext) {
+	l.events = append(l.events, exprParser_ruleNames[ctx.GetRuleIndex()])
+}
+
+func (l *treeTestSkippingListener) ExitEveryRule(ctx ParserRuleContext) {
+	l.events = append(l.events, "/"+exprParser_ruleNames[ctx.GetRuleIndex()])
+}
+
+func (l *treeTestSkippingListener) VisitTerminal(node TerminalNode) {
+	l.events = append(l.events, node.GetText())
+}
+
+func (l *treeTestSkippingListener) ShouldDescend(ctx ParserRuleContext) bool {
+	return ctx.GetRuleIndex() != ExprParserRULE_stat
+}
+
+func TestParseTreeWalkerSkippingListener(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(a) { x; }").Func_()
+
+	l := &treeTestSkippingListener{BaseParseTreeListener: &BaseParseTreeListener{}}
+	ParseTreeWalkerDefault.Walk(l, tree)
+	assert.Equal([]string{
+		"func_", "def", "f", "(", "arg", "a", "/arg", ")",
+		"body", "{", "stat", "/stat", "}", "/body",
+		"/func_",
+	}, l.events)
+}
+
+// treeTestUnescape removes the quotes around s and the backslash before
+// each escaped character.
+func treeTestUnescape(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", errors.New("missing quotes")
+	}
+	var b strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' {
+			i++
+			if i == len(s)-1 {
+				return "", errors.New("incomplete escape")
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String(), nil
+}
+
+func TestTerminalNodeImplDecodedText(t *testing.T) {
+	assert := assertNew(t)
+	tok := newTestCommonToken(ExprLexerID, `"say \"hi\" \\o/"`, TokenDefaultChannel)
+	tok.line, tok.column = 3, 7
+	node := NewTerminalNodeImpl(tok)
+
+	decoded, err := node.DecodedText(treeTestUnescape)
+	assert.Nil(err)
+	assert.Equal(`say "hi" \o/`, decoded)
+
+	raw, err := node.DecodedText(nil)
+	assert.Nil(err)
+	assert.Equal(tok.GetText(), raw)
+
+	tok.text = `"trailing \"`
+	_, err = node.DecodedText(treeTestUnescape)
+	assert.Equal("line 3:7: incomplete escape", err.Error())
+}
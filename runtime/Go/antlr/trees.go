@@ -4,7 +4,10 @@
 
 package antlr
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 /** A set of utility routines useful for all kinds of ANTLR trees. */
 
@@ -46,6 +49,9 @@ func TreesGetNodeText(t Tree, ruleNames []string, recog Parser) string {
 		switch t2 := t.(type) {
 		case RuleNode:
 			t3 := t2.GetRuleContext()
+			if t3.GetRuleIndex() == ForestRuleIndex {
+				return "<forest>"
+			}
 			altNumber := t3.GetAltNumber()
 
 			if altNumber != ATNInvalidAltNumber {
@@ -83,16 +89,71 @@ func TreesGetChildren(t Tree) []Tree {
 //  list is the root and the last is the parent of this node.
 //
 func TreesgetAncestors(t Tree) []Tree {
+	return TreesGetAncestors(t)
+}
+
+// TreesGetAncestors returns the rule nodes enclosing t, following parent
+// pointers. The list is ordered root first, so the last node is the parent
+// of t, which suits breadcrumbs such as "func_ > body > stat". It is empty
+// for the root.
+func TreesGetAncestors(t Tree) []Tree {
 	ancestors := make([]Tree, 0)
-	t = t.GetParent()
-	for t != nil {
-		f := []Tree{t}
-		ancestors = append(f, ancestors...)
-		t = t.GetParent()
+	for t = treesParent(t); t != nil; t = treesParent(t) {
+		ancestors = append(ancestors, t)
+	}
+	for i, j := 0, len(ancestors)-1; i < j; i, j = i+1, j-1 {
+		ancestors[i], ancestors[j] = ancestors[j], ancestors[i]
 	}
 	return ancestors
 }
 
+// TreesGetLowestCommonAncestor returns the deepest node that is an ancestor
+// of both a and b, following parent pointers. A node counts as its own
+// ancestor, so if a is an ancestor of b the result is a. It returns nil if
+// either node is nil or the nodes belong to different trees.
+func TreesGetLowestCommonAncestor(a, b Tree) Tree {
+	if a == nil || b == nil {
+		return nil
+	}
+	ancestors := make(map[interface{}]bool)
+	for t := a; t != nil; t = treesParent(t) {
+		ancestors[treesNodeKey(t)] = true
+	}
+	for t := b; t != nil; t = treesParent(t) {
+		if ancestors[treesNodeKey(t)] {
+			return t
+		}
+	}
+	return nil
+}
+
+// treesParent returns the parent of t. The parent of a terminal node is the
+// BaseParserRuleContext embedded in the context that added it, so in that
+// case the context itself is looked up among the children of its parent.
+func treesParent(t Tree) Tree {
+	parent := t.GetParent()
+	if b, ok := parent.(*BaseParserRuleContext); ok {
+		if grandparent := b.GetParent(); grandparent != nil {
+			for i := 0; i < grandparent.GetChildCount(); i++ {
+				if child := grandparent.GetChild(i); treesNodeKey(child) == treesNodeKey(b) {
+					return child
+				}
+			}
+		}
+	}
+	return parent
+}
+
+// treesNodeKey returns the BaseRuleContext embedded in t if t is a rule
+// node, and t otherwise, so that a context and its embedded
+// BaseParserRuleContext compare equal.
+func treesNodeKey(t Tree) interface{} {
+	if ctx, ok := t.(RuleNode); ok {
+		return ctx.GetBaseRuleContext()
+	}
+	return t
+}
+
 func TreesFindAllTokenNodes(t ParseTree, ttype int) []ParseTree {
 	return TreesfindAllNodes(t, ttype, true)
 }
@@ -135,3 +196,113 @@ func TreesDescendants(t ParseTree) []ParseTree {
 	}
 	return nodes
 }
+
+// TreesFanoutHistogram maps each child count observed on a rule node of t to
+// the number of rule nodes having that many children. Terminal nodes are not
+// counted.
+func TreesFanoutHistogram(t ParseTree) map[int]int {
+	histogram := make(map[int]int)
+	treesFanoutHistogram(t, histogram)
+	return histogram
+}
+
+func treesFanoutHistogram(t Tree, histogram map[int]int) {
+	if _, ok := t.(RuleNode); !ok {
+		return
+	}
+	c := t.GetChildCount()
+	histogram[c]++
+	for i := 0; i < c; i++ {
+		treesFanoutHistogram(t.GetChild(i), histogram)
+	}
+}
+
+// TreesTerminalsOnChannel returns the terminal nodes of t, in document order,
+// whose symbol is on channel. Error nodes are terminals too and are included.
+//
+// A parser only adds the tokens it matches to the tree, so a tree built by a
+// parser normally holds default channel terminals only and the result for
+// any other channel is empty. Hidden channel terminals are only found in
+// trees that had them added explicitly, for example with AddTokenNode.
+func TreesTerminalsOnChannel(t ParseTree, channel int) []TerminalNode {
+	nodes := make([]TerminalNode, 0)
+	treesTerminalsOnChannel(t, channel, &nodes)
+	return nodes
+}
+
+func treesTerminalsOnChannel(t Tree, channel int, nodes *[]TerminalNode) {
+	if n, ok := t.(TerminalNode); ok {
+		if n.GetSymbol() != nil && n.GetSymbol().GetChannel() == channel {
+			*nodes = append(*nodes, n)
+		}
+		return
+	}
+	for i := 0; i < t.GetChildCount(); i++ {
+		treesTerminalsOnChannel(t.GetChild(i), channel, nodes)
+	}
+}
+
+// TreesSortChildren reorders the children of node in place so that they are
+// sorted by less, keeping the original order of children that compare
+// equal, and sets the parent of every child to node. It panics if node is a
+// terminal node, which has no children to sort.
+func TreesSortChildren(node ParseTree, less func(a, b ParseTree) bool) {
+	ctx, ok := node.(ParserRuleContext)
+	if !ok {
+		panic("TreesSortChildren requires a rule node")
+	}
+	children := ctx.GetChildren()
+	sort.SliceStable(children, func(i, j int) bool {
+		return less(children[i].(ParseTree), children[j].(ParseTree))
+	})
+	for _, child := range children {
+		child.SetParent(ctx)
+	}
+}
+
+// Kinds of TreeNodeInfo.
+const (
+	TreeNodeKindRule     = "rule"
+	TreeNodeKindTerminal = "terminal"
+	TreeNodeKindError    = "error"
+)
+
+// TreeNodeInfo describes a node of the tree exported by TreesAdjacencyList.
+// ID is the index of the node in nodes, which is its index in a pre-order
+// walk, Kind one of the TreeNodeKind constants and Label the text of the
+// node as in TreesGetNodeText.
+type TreeNodeInfo struct {
+	ID    int
+	Kind  string
+	Label string
+}
+
+// TreesAdjacencyList flattens t into a list of nodes, in pre-order so the
+// root has id 0, and a list of parent to child edges between node ids, for
+// export to graph tools. Rule nodes are labeled with their name from
+// ruleNames. Edges are listed in pre-order of the child, so the edges of a
+// node appear in the order of its children.
+func TreesAdjacencyList(t ParseTree, ruleNames []string) (nodes []TreeNodeInfo, edges [][2]int) {
+	nodes = make([]TreeNodeInfo, 0)
+	edges = make([][2]int, 0)
+	treesAdjacencyList(t, ruleNames, -1, &nodes, &edges)
+	return nodes, edges
+}
+
+func treesAdjacencyList(t Tree, ruleNames []string, parent int, nodes *[]TreeNodeInfo, edges *[][2]int) {
+	id := len(*nodes)
+	kind := TreeNodeKindRule
+	switch t.(type) {
+	case ErrorNode:
+		kind = TreeNodeKindError
+	case TerminalNode:
+		kind = TreeNodeKindTerminal
+	}
+	*nodes = append(*nodes, TreeNodeInfo{ID: id, Kind: kind, Label: TreesGetNodeText(t, ruleNames, nil)})
+	if parent >= 0 {
+		*edges = append(*edges, [2]int{parent, id})
+	}
+	for i := 0; i < t.GetChildCount(); i++ {
+		treesAdjacencyList(t.GetChild(i), ruleNames, id, nodes, edges)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// TreesJSONOption configures TreesToJSON.
+type TreesJSONOption func(*treesJSONConfig)
+
+type treesJSONConfig struct {
+	nodeIDs bool
+}
+
+// WithNodeIDs makes TreesToJSON give every node an "id" member: the index of
+// the node in a pre-order walk of the tree, 0 for the root. The ids only
+// depend on the shape of the tree, so serializing the same tree again gives
+// the same ids, and other documents can refer to the nodes by id.
+func WithNodeIDs(enabled bool) TreesJSONOption {
+	return func(c *treesJSONConfig) {
+		c.nodeIDs = enabled
+	}
+}
+
+// treesJSONNode is the JSON form of a node: rule nodes have a rule name and
+// children, terminal and error nodes a token type and text.
+type treesJSONNode struct {
+	ID       *int             `json:"id,omitempty"`
+	Rule     string           `json:"rule,omitempty"`
+	Type     *int             `json:"type,omitempty"`
+	Text     *string          `json:"text,omitempty"`
+	Error    bool             `json:"error,omitempty"`
+	Children []*treesJSONNode `json:"children,omitempty"`
+}
+
+// TreesToJSON serializes t as a JSON object per node. A rule node has its
+// rule name, taken from ruleNames, as "rule" and its children as
+// "children"; a terminal has the type and text of its token as "type" and
+// "text", and error nodes additionally have "error": true. For example
+//
+//	{"rule":"stat","children":[{"type":14,"text":"x"},{"type":7,"text":";"}]}
+func TreesToJSON(t ParseTree, ruleNames []string, options ...TreesJSONOption) ([]byte, error) {
+	if t == nil {
+		return nil, errors.New("TreesToJSON requires a non-nil tree")
+	}
+	config := new(treesJSONConfig)
+	for _, option := range options {
+		option(config)
+	}
+
+	next := 0
+	return json.Marshal(treesJSONBuild(t, ruleNames, config, &next))
+}
+
+// treesJSONBuild converts t, whose pre-order index is *next, and its
+// descendants.
+func treesJSONBuild(t Tree, ruleNames []string, config *treesJSONConfig, next *int) *treesJSONNode {
+	n := new(treesJSONNode)
+	if config.nodeIDs {
+		id := *next
+		n.ID = &id
+	}
+	*next++
+
+	if tn, ok := t.(TerminalNode); ok {
+		tokenType, text := tn.GetSymbol().GetTokenType(), tn.GetText()
+		n.Type, n.Text = &tokenType, &text
+		_, n.Error = t.(ErrorNode)
+		return n
+	}
+	n.Rule = TreesGetNodeText(t, ruleNames, nil)
+	for i := 0; i < t.GetChildCount(); i++ {
+		n.Children = append(n.Children, treesJSONBuild(t.GetChild(i), ruleNames, config, next))
+	}
+	return n
+}
+
+// Events written by ParseTreeWalker.WalkJSON.
+const (
+	WalkJSONEnter    = "enter"
+	WalkJSONExit     = "exit"
+	WalkJSONTerminal = "terminal"
+	WalkJSONError    = "error"
+)
+
+// walkJSONEvent is an event of WalkJSON: rule events have a rule name,
+// terminal and error events a token text.
+type walkJSONEvent struct {
+	Event string  `json:"event"`
+	Rule  string  `json:"rule,omitempty"`
+	Text  *string `json:"text,omitempty"`
+	Depth int     `json:"depth"`
+}
+
+// WalkJSON walks t like WalkWithDepth and writes the events to w as a JSON
+// array with one object per event, each on its own line:
+//
+//	[
+//	{"event":"enter","rule":"stat","depth":0},
+//	{"event":"terminal","text":";","depth":1},
+//	{"event":"exit","rule":"stat","depth":0}
+//	]
+//
+// The event is one of the WalkJSON constants. Rule events have the rule
+// name, taken from ruleNames, as "rule"; terminal and error events the text
+// of their token as "text". The walk stops at the first error writing to w,
+// which is returned.
+func (p *ParseTreeWalker) WalkJSON(t Tree, ruleNames []string, w io.Writer) (err error) {
+	l := &walkJSONListener{w: w, ruleNames: ruleNames}
+	defer func() {
+		if e := recover(); e != nil {
+			if _, ok := e.(walkJSONStop); !ok {
+				panic(e)
+			}
+			err = l.err
+		}
+	}()
+	l.write("[")
+	p.WalkWithDepth(l, t)
+	l.write("\n]\n")
+	return nil
+}
+
+// walkJSONStop is panicked by a walkJSONListener to end the walk when
+// writing fails.
+type walkJSONStop struct{}
+
+type walkJSONListener struct {
+	w         io.Writer
+	ruleNames []string
+	events    int
+	err       error
+}
+
+func (l *walkJSONListener) write(s string) {
+	if _, err := io.WriteString(l.w, s); err != nil {
+		l.fail(err)
+	}
+}
+
+// fail records err and stops the walk.
+func (l *walkJSONListener) fail(err error) {
+	l.err = err
+	panic(walkJSONStop{})
+}
+
+func (l *walkJSONListener) event(e walkJSONEvent) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		l.fail(err)
+	}
+	if l.events > 0 {
+		l.write(",")
+	}
+	l.events++
+	l.write("\n" + string(b))
+}
+
+func (l *walkJSONListener) EnterRuleAtDepth(ctx ParserRuleContext, depth int) {
+	l.event(walkJSONEvent{Event: WalkJSONEnter, Rule: TreesGetNodeText(ctx, l.ruleNames, nil), Depth: depth})
+}
+
+func (l *walkJSONListener) ExitRuleAtDepth(ctx ParserRuleContext, depth int) {
+	l.event(walkJSONEvent{Event: WalkJSONExit, Rule: TreesGetNodeText(ctx, l.ruleNames, nil), Depth: depth})
+}
+
+func (l *walkJSONListener) VisitTerminalAtDepth(node TerminalNode, depth int) {
+	text := node.GetText()
+	l.event(walkJSONEvent{Event: WalkJSONTerminal, Text: &text, Depth: depth})
+}
+
+func (l *walkJSONListener) VisitErrorNodeAtDepth(node ErrorNode, depth int) {
+	text := node.GetText()
+	l.event(walkJSONEvent{Event: WalkJSONError, Text: &text, Depth: depth})
+}
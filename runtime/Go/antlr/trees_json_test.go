@@ -0,0 +1,131 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestTreesToJSON(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("x;").Stat()
+
+	out, err := TreesToJSON(tree, exprParser_ruleNames)
+	assert.Nil(err)
+	assert.Equal(`{"rule":"stat","children":[{"rule":"expr","children":[{"rule":"primary","children":[{"type":14,"text":"x"}]}]},{"type":7,"text":";"}]}`, string(out))
+
+	_, err = TreesToJSON(nil, exprParser_ruleNames)
+	assert.NotNil(err)
+}
+
+func TestTreesToJSONWithNodeIDs(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("x;").Stat()
+
+	out, err := TreesToJSON(tree, exprParser_ruleNames, WithNodeIDs(true))
+	assert.Nil(err)
+	assert.Equal(`{"id":0,"rule":"stat","children":[{"id":1,"rule":"expr","children":[{"id":2,"rule":"primary","children":[{"id":3,"type":14,"text":"x"}]}]},{"id":4,"type":7,"text":";"}]}`, string(out))
+
+	again, err := TreesToJSON(tree, exprParser_ruleNames, WithNodeIDs(true))
+	assert.Nil(err)
+	assert.Equal(string(out), string(again))
+	reparsed, err := TreesToJSON(newExprParserFor("x;").Stat(), exprParser_ruleNames, WithNodeIDs(true))
+	assert.Nil(err)
+	assert.Equal(string(out), string(reparsed))
+}
+
+func TestParseTreeWalkerWalkJSON(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x = 1;")
+	tree := p.Stat()
+
+	var buf bytes.Buffer
+	assert.Nil(ParseTreeWalkerDefault.WalkJSON(tree, exprParser_ruleNames, &buf))
+	assert.Equal(`[
+{"event":"enter","rule":"stat","depth":0},
+{"event":"terminal","text":"x","depth":1},
+{"event":"terminal","text":"=","depth":1},
+{"event":"enter","rule":"expr","depth":1},
+{"event":"enter","rule":"primary","depth":2},
+{"event":"terminal","text":"1","depth":3},
+{"event":"exit","rule":"primary","depth":2},
+{"event":"exit","rule":"expr","depth":1},
+{"event":"terminal","text":";","depth":1},
+{"event":"exit","rule":"stat","depth":0}
+]
+`, buf.String())
+
+	var events []map[string]interface{}
+	assert.Nil(json.Unmarshal(buf.Bytes(), &events))
+	assert.Equal(10, len(events))
+
+	err := ParseTreeWalkerDefault.WalkJSON(tree, exprParser_ruleNames, &treesJSONTestFailingWriter{})
+	assert.NotNil(err)
+}
+
+func TestParseTreeWalkerWalkJSONStopsOnError(t *testing.T) {
+	assert := assertNew(t)
+	visited := 0
+	root := NewBaseParserRuleContext(nil, -1)
+	root.RuleIndex = ExprParserRULE_stat
+	for i := 0; i < 5; i++ {
+		root.AddTokenNode(&treesJSONTestToken{newTestCommonToken(ExprLexerID, "x", TokenDefaultChannel), &visited})
+	}
+
+	w := &treesJSONTestFailingWriter{ok: 4}
+	assert.Equal("write failed", ParseTreeWalkerDefault.WalkJSON(root, exprParser_ruleNames, w).Error())
+	// "[", the enter event and the first terminal with its comma are written,
+	// the comma before the second terminal fails and ends the walk
+	assert.Equal(5, w.writes)
+	assert.Equal(2, visited)
+}
+
+func TestParseTreeWalkerWalkJSONErrorNodes(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x 1;")
+	p.RemoveErrorListeners()
+	tree := p.Stat()
+
+	var buf bytes.Buffer
+	assert.Nil(ParseTreeWalkerDefault.WalkJSON(tree, exprParser_ruleNames, &buf))
+	var events []walkJSONEvent
+	assert.Nil(json.Unmarshal(buf.Bytes(), &events))
+	var errorTexts []string
+	for _, e := range events {
+		if e.Event == WalkJSONError {
+			errorTexts = append(errorTexts, *e.Text)
+		}
+	}
+	assert.Equal([]string{"x", "1", ";"}, errorTexts)
+}
+
+// treesJSONTestFailingWriter accepts ok writes and fails all later ones.
+type treesJSONTestFailingWriter struct {
+	ok     int
+	writes int
+}
+
+func (w *treesJSONTestFailingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes > w.ok {
+		return 0, errors.New("write failed")
+	}
+	return len(p), nil
+}
+
+// treesJSONTestToken counts the calls to GetText.
+type treesJSONTestToken struct {
+	*CommonToken
+
+	calls *int
+}
+
+func (t *treesJSONTestToken) GetText() string {
+	*t.calls++
+	return t.CommonToken.GetText()
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// Layout of the SVG rendering, in pixels.
+const (
+	treesSVGCharWidth  = 8  // approximate width of a monospace character
+	treesSVGPadding    = 6  // space between a label and the side of its box
+	treesSVGBoxHeight  = 22 // height of a node box
+	treesSVGLevelGap   = 28 // vertical space between a node and its children
+	treesSVGSiblingGap = 10 // horizontal space between sibling subtrees
+	treesSVGMargin     = 10 // space around the tree
+)
+
+// treesSVGNode is a node of the tree laid out for TreesToSVG. x is the
+// center of the box and y its top; width is the width of the box and
+// subtreeWidth the width taken by the node and all its descendants.
+type treesSVGNode struct {
+	label        string
+	isError      bool
+	isRule       bool
+	x, y         int
+	width        int
+	subtreeWidth int
+	children     []*treesSVGNode
+}
+
+// TreesToSVG renders t as an SVG image: each node is a box labeled with its
+// rule name, taken from ruleNames, or its token text, connected to its
+// children by lines. Rule nodes, terminals and error nodes are drawn in
+// different colors.
+//
+// The layout is a simple tidy tree: every subtree is given the width of its
+// widest level, children are placed from left to right below their parent
+// and the parent is centered above them. The output only depends on the
+// tree, so it can be compared to a reference image.
+func TreesToSVG(t ParseTree, ruleNames []string) ([]byte, error) {
+	if t == nil {
+		return nil, errors.New("TreesToSVG requires a non-nil tree")
+	}
+
+	root := treesSVGMeasure(t, ruleNames)
+	treesSVGPlace(root, treesSVGMargin, treesSVGMargin)
+	width := root.subtreeWidth + 2*treesSVGMargin
+	height := treesSVGDepth(root)*(treesSVGBoxHeight+treesSVGLevelGap) - treesSVGLevelGap + 2*treesSVGMargin
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", width, height, width, height)
+	fmt.Fprintf(&buf, "<g stroke=\"#555555\">\n")
+	treesSVGWriteLines(&buf, root)
+	fmt.Fprintf(&buf, "</g>\n")
+	fmt.Fprintf(&buf, "<g font-family=\"monospace\" font-size=\"13\" text-anchor=\"middle\">\n")
+	if err := treesSVGWriteNodes(&buf, root); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&buf, "</g>\n</svg>\n")
+
+	return buf.Bytes(), nil
+}
+
+func treesSVGMeasure(t Tree, ruleNames []string) *treesSVGNode {
+	n := new(treesSVGNode)
+	n.label = EscapeWhitespace(TreesGetNodeText(t, ruleNames, nil), false)
+	_, n.isError = t.(ErrorNode)
+	_, n.isRule = t.(RuleNode)
+	n.width = len([]rune(n.label))*treesSVGCharWidth + 2*treesSVGPadding
+
+	childrenWidth := 0
+	for i := 0; i < t.GetChildCount(); i++ {
+		child := treesSVGMeasure(t.GetChild(i), ruleNames)
+		if i > 0 {
+			childrenWidth += treesSVGSiblingGap
+		}
+		childrenWidth += child.subtreeWidth
+		n.children = append(n.children, child)
+	}
+	n.subtreeWidth = intMax(n.width, childrenWidth)
+
+	return n
+}
+
+// treesSVGPlace positions n and its descendants in the area starting at
+// left, with n at the top y.
+func treesSVGPlace(n *treesSVGNode, left, y int) {
+	n.x = left + n.subtreeWidth/2
+	n.y = y
+
+	childrenWidth := -treesSVGSiblingGap
+	for _, child := range n.children {
+		childrenWidth += child.subtreeWidth + treesSVGSiblingGap
+	}
+	childLeft := left + (n.subtreeWidth-childrenWidth)/2
+	for _, child := range n.children {
+		treesSVGPlace(child, childLeft, y+treesSVGBoxHeight+treesSVGLevelGap)
+		childLeft += child.subtreeWidth + treesSVGSiblingGap
+	}
+}
+
+func treesSVGDepth(n *treesSVGNode) int {
+	depth := 0
+	for _, child := range n.children {
+		depth = intMax(depth, treesSVGDepth(child))
+	}
+	return depth + 1
+}
+
+func treesSVGWriteLines(buf *bytes.Buffer, n *treesSVGNode) {
+	for _, child := range n.children {
+		fmt.Fprintf(buf, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\"/>\n", n.x, n.y+treesSVGBoxHeight, child.x, child.y)
+		treesSVGWriteLines(buf, child)
+	}
+}
+
+func treesSVGWriteNodes(buf *bytes.Buffer, n *treesSVGNode) error {
+	fill, stroke := "#ffffff", "#555555"
+	if n.isError {
+		fill, stroke = "#f8d7da", "#c0392b"
+	} else if n.isRule {
+		fill = "#e3ecfa"
+	}
+	fmt.Fprintf(buf, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"4\" fill=\"%s\" stroke=\"%s\"/>\n",
+		n.x-n.width/2, n.y, n.width, treesSVGBoxHeight, fill, stroke)
+	fmt.Fprintf(buf, "<text x=\"%d\" y=\"%d\">", n.x, n.y+treesSVGBoxHeight-7)
+	if err := xml.EscapeText(buf, []byte(n.label)); err != nil {
+		return err
+	}
+	buf.WriteString("</text>\n")
+
+	for _, child := range n.children {
+		if err := treesSVGWriteNodes(buf, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+func TestTreesFanoutHistogram(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x = 1+2*3; }")
+	tree := p.Prog()
+
+	// (prog (func_ def f ( (arg x) ) (body { (stat x = (expr (expr (primary 1)) + (expr (expr (primary 2)) * (expr (primary 3)))) ;) })))
+	assert.Equal(map[int]int{1: 8, 3: 3, 4: 1, 6: 1}, TreesFanoutHistogram(tree))
+}
+
+func TestTreesFanoutHistogramLeafRule(t *testing.T) {
+	assert := assertNew(t)
+
+	assert.Equal(map[int]int{0: 1}, TreesFanoutHistogram(NewBaseParserRuleContext(nil, -1)))
+	assert.Equal(map[int]int{}, TreesFanoutHistogram(NewTerminalNodeImpl(newTestCommonToken(1, "x", 0))))
+}
+
+func TestTreesTerminalsOnChannel(t *testing.T) {
+	assert := assertNew(t)
+	root := NewBaseParserRuleContext(nil, -1)
+	root.AddTokenNode(newTestCommonToken(ExprLexerID, "x", TokenDefaultChannel))
+	root.AddTokenNode(newTestCommonToken(ExprLexerWS, " ", TokenHiddenChannel))
+	child := NewBaseParserRuleContext(root, -1)
+	root.AddChild(child)
+	child.AddTokenNode(newTestCommonToken(ExprLexerT__7, "=", TokenDefaultChannel))
+	child.AddTokenNode(newTestCommonToken(ExprLexerWS, "  ", TokenHiddenChannel))
+	child.AddErrorNode(newTestCommonToken(ExprLexerWS, "\t", TokenHiddenChannel))
+	root.AddTokenNode(newTestCommonToken(ExprLexerINT, "1", TokenDefaultChannel))
+
+	texts := func(nodes []TerminalNode) []string {
+		s := make([]string, len(nodes))
+		for i, n := range nodes {
+			s[i] = n.GetText()
+		}
+		return s
+	}
+	assert.Equal([]string{" ", "  ", "\t"}, texts(TreesTerminalsOnChannel(root, TokenHiddenChannel)))
+	assert.Equal([]string{"x", "=", "1"}, texts(TreesTerminalsOnChannel(root, TokenDefaultChannel)))
+	assert.Equal([]string{}, texts(TreesTerminalsOnChannel(root, 2)))
+
+	// a terminal without a symbol is on no channel
+	child.AddTokenNode(nil)
+	assert.Equal([]string{"x", "=", "1"}, texts(TreesTerminalsOnChannel(root, TokenDefaultChannel)))
+}
+
+func TestTreesTerminalsOnChannelParsedTree(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(x) { x = 1; }").Prog()
+
+	assert.Equal(0, len(TreesTerminalsOnChannel(tree, TokenHiddenChannel)))
+	assert.Equal(11, len(TreesTerminalsOnChannel(tree, TokenDefaultChannel)))
+}
+
+func TestTreesGetLowestCommonAncestor(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x = 1+2*3; }")
+	tree := p.Prog()
+
+	// (prog (func_ def f ( (arg x) ) (body { (stat x = (expr (expr (primary 1)) + (expr (expr (primary 2)) * (expr (primary 3)))) ;) })))
+	stat := TreesfindAllRuleNodes(tree, ExprParserRULE_stat)[0]
+	primaries := TreesfindAllRuleNodes(tree, ExprParserRULE_primary)
+	ints := TreesFindAllTokenNodes(tree, ExprParserINT)
+	mul := TreesFindAllTokenNodes(tree, ExprParserMUL)[0]
+	arg := TreesfindAllRuleNodes(tree, ExprParserRULE_arg)[0]
+
+	sum := stat.GetChild(2)
+	product := sum.GetChild(2)
+	assert.Equal(sum, TreesGetLowestCommonAncestor(ints[0], ints[2]))
+	assert.Equal(product, TreesGetLowestCommonAncestor(primaries[1], primaries[2]))
+	assert.Equal(product, TreesGetLowestCommonAncestor(ints[1], mul))
+	assert.Equal(tree.GetChild(0), TreesGetLowestCommonAncestor(arg, ints[0]))
+
+	// one node is an ancestor of the other
+	assert.Equal(stat, TreesGetLowestCommonAncestor(stat, ints[2]))
+	assert.Equal(stat, TreesGetLowestCommonAncestor(ints[2], stat))
+	assert.Equal(mul, TreesGetLowestCommonAncestor(mul, mul))
+}
+
+func TestTreesGetLowestCommonAncestorUnrelated(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(x) { x = 1; }").Prog()
+	other := newExprParserFor("def f(x) { x = 1; }").Prog()
+
+	assert.Nil(TreesGetLowestCommonAncestor(tree, other))
+	assert.Nil(TreesGetLowestCommonAncestor(tree.GetChild(0), other.GetChild(0)))
+	assert.Nil(TreesGetLowestCommonAncestor(tree, nil))
+	assert.Nil(TreesGetLowestCommonAncestor(nil, tree))
+}
+
+func TestTreesGetLowestCommonAncestorTerminals(t *testing.T) {
+	assert := assertNew(t)
+	tree := newExprParserFor("def f(x) { x = 1; }").Prog()
+
+	// terminals whose parent is the same labeled context
+	stat := TreesfindAllRuleNodes(tree, ExprParserRULE_stat)[0]
+	lca := TreesGetLowestCommonAncestor(stat.GetChild(0), stat.GetChild(1))
+	_, ok := lca.(*AssignContext)
+	assert.Equal(true, ok)
+	assert.Equal(stat, lca)
+}
+
+func TestTreesSortChildren(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(c, a, b) { }")
+	tree := p.Prog()
+	func_ := TreesfindAllRuleNodes(tree, ExprParserRULE_func_)[0].(ParserRuleContext)
+
+	byText := func(a, b ParseTree) bool { return a.GetText() < b.GetText() }
+	TreesSortChildren(func_, byText)
+
+	// "(" < ")" < "," < "a" < "b" < "c" < "def" < "f" < "{}"
+	assert.Equal("(func_ ( ) , , (arg a) (arg b) (arg c) def f (body { }))", TreesStringTree(func_, nil, p))
+	for _, child := range func_.GetChildren() {
+		assert.Equal(func_, child.GetParent())
+	}
+	assert.Equal(tree, func_.GetParent())
+}
+
+func TestTreesSortChildrenTerminal(t *testing.T) {
+	assert := assertNew(t)
+	node := NewTerminalNodeImpl(newTestCommonToken(ExprLexerID, "x", TokenDefaultChannel))
+
+	assert.Panics(func() {
+		TreesSortChildren(node, func(a, b ParseTree) bool { return false })
+	})
+}
+
+func TestTreesGetAncestors(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x = (1+2)*3; }")
+	tree := p.Prog()
+
+	names := func(nodes []Tree) []string {
+		s := make([]string, len(nodes))
+		for i, n := range nodes {
+			s[i] = TreesGetNodeText(n, nil, p)
+		}
+		return s
+	}
+
+	one := TreesFindAllTokenNodes(tree, ExprParserINT)[0]
+	assert.Equal([]string{"prog", "func_", "body", "stat", "expr", "expr", "primary", "expr", "expr", "primary"}, names(TreesGetAncestors(one)))
+	ancestors := TreesGetAncestors(one)
+	assert.Equal(tree, ancestors[0])
+	_, ok := ancestors[len(ancestors)-1].(*IntContext)
+	assert.Equal(true, ok)
+
+	assert.Equal([]Tree{}, TreesGetAncestors(tree))
+}
+
+func TestTreesToSVG(t *testing.T) {
+	assert := assertNew(t)
+	stat := NewBaseParserRuleContext(nil, -1)
+	stat.RuleIndex = ExprParserRULE_stat
+	stat.AddTokenNode(newTestCommonToken(ExprLexerID, "x", TokenDefaultChannel))
+	stat.AddErrorNode(newTestCommonToken(ExprLexerMUL, "<*>", TokenDefaultChannel))
+	expr := NewBaseParserRuleContext(stat, -1)
+	expr.RuleIndex = ExprParserRULE_expr
+	stat.AddChild(expr)
+	expr.AddTokenNode(newTestCommonToken(ExprLexerINT, "1", TokenDefaultChannel))
+
+	svg, err := TreesToSVG(stat, exprParser_ruleNames)
+	assert.Nil(err)
+	assert.Equal(`<svg xmlns="http://www.w3.org/2000/svg" width="140" height="142" viewBox="0 0 140 142">
+<g stroke="#555555">
+<line x1="70" y1="32" x2="20" y2="60"/>
+<line x1="70" y1="32" x2="58" y2="60"/>
+<line x1="70" y1="32" x2="108" y2="60"/>
+<line x1="108" y1="82" x2="108" y2="110"/>
+</g>
+<g font-family="monospace" font-size="13" text-anchor="middle">
+<rect x="48" y="10" width="44" height="22" rx="4" fill="#e3ecfa" stroke="#555555"/>
+<text x="70" y="25">stat</text>
+<rect x="10" y="60" width="20" height="22" rx="4" fill="#ffffff" stroke="#555555"/>
+<text x="20" y="75">x</text>
+<rect x="40" y="60" width="36" height="22" rx="4" fill="#f8d7da" stroke="#c0392b"/>
+<text x="58" y="75">&lt;*&gt;</text>
+<rect x="86" y="60" width="44" height="22" rx="4" fill="#e3ecfa" stroke="#555555"/>
+<text x="108" y="75">expr</text>
+<rect x="98" y="110" width="20" height="22" rx="4" fill="#ffffff" stroke="#555555"/>
+<text x="108" y="125">1</text>
+</g>
+</svg>
+`, string(svg))
+
+	again, _ := TreesToSVG(stat, exprParser_ruleNames)
+	assert.Equal(string(svg), string(again))
+
+	_, err = TreesToSVG(nil, exprParser_ruleNames)
+	assert.NotNil(err)
+}
+
+func TestTreesAdjacencyList(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("x = 1 2;")
+	p.RemoveErrorListeners()
+	stat := p.Stat()
+
+	nodes, edges := TreesAdjacencyList(stat, exprParser_ruleNames)
+	assert.Equal(8, len(nodes))
+	assert.Equal(7, len(edges))
+	assert.Equal([]TreeNodeInfo{
+		{0, TreeNodeKindRule, "stat"},
+		{1, TreeNodeKindTerminal, "x"},
+		{2, TreeNodeKindTerminal, "="},
+		{3, TreeNodeKindRule, "expr"},
+		{4, TreeNodeKindRule, "primary"},
+		{5, TreeNodeKindTerminal, "1"},
+		{6, TreeNodeKindError, "2"},
+		{7, TreeNodeKindTerminal, ";"},
+	}, nodes)
+	assert.Equal([][2]int{{0, 1}, {0, 2}, {0, 3}, {3, 4}, {4, 5}, {0, 6}, {0, 7}}, edges)
+}
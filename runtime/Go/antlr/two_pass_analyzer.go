@@ -0,0 +1,62 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+// TwoPassAnalyzer is a scaffold for analyses that need to see every
+// declaration before resolving references, such as a simple type checker.
+// The tree is walked twice with the same walker: DeclarationPass collects
+// declarations into the symbol table and ResolutionPass then looks them up,
+// so a reference may appear before the declaration it refers to.
+//
+// Listeners get at the shared symbol table through Declare and Resolve.
+type TwoPassAnalyzer struct {
+	tree     ParseTree
+	walker   *ParseTreeWalker
+	symbols  map[string]interface{}
+	declared bool
+}
+
+// NewTwoPassAnalyzer creates an analyzer for tree with an empty symbol
+// table, walking the tree with ParseTreeWalkerDefault.
+func NewTwoPassAnalyzer(tree ParseTree) *TwoPassAnalyzer {
+	return &TwoPassAnalyzer{
+		tree:    tree,
+		walker:  ParseTreeWalkerDefault,
+		symbols: make(map[string]interface{}),
+	}
+}
+
+// DeclarationPass walks the tree with listener, which is expected to record
+// declarations with Declare.
+func (a *TwoPassAnalyzer) DeclarationPass(listener ParseTreeListener) {
+	a.walker.Walk(listener, a.tree)
+	a.declared = true
+}
+
+// ResolutionPass walks the tree with listener, which is expected to look up
+// references with Resolve. It panics if DeclarationPass has not been run.
+func (a *TwoPassAnalyzer) ResolutionPass(listener ParseTreeListener) {
+	if !a.declared {
+		panic("ResolutionPass requires a DeclarationPass first")
+	}
+	a.walker.Walk(listener, a.tree)
+}
+
+// Declare records value, typically the declaring node or its semantic type,
+// under name. A later declaration of the same name replaces the earlier one.
+func (a *TwoPassAnalyzer) Declare(name string, value interface{}) {
+	a.symbols[name] = value
+}
+
+// Resolve returns the value declared under name and whether it was found.
+func (a *TwoPassAnalyzer) Resolve(name string) (interface{}, bool) {
+	value, ok := a.symbols[name]
+	return value, ok
+}
+
+// GetTree returns the tree the analyzer walks.
+func (a *TwoPassAnalyzer) GetTree() ParseTree {
+	return a.tree
+}
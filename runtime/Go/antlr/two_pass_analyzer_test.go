@@ -0,0 +1,68 @@
+// Copyright (c) 2012-2017 The ANTLR Project. All rights reserved.
+// Use of this file is governed by the BSD 3-clause license that
+// can be found in the LICENSE.txt file in the project root.
+
+package antlr
+
+import (
+	"testing"
+)
+
+// twoPassAnalyzerTestDeclarations declares every function by name.
+type twoPassAnalyzerTestDeclarations struct {
+	*BaseParseTreeListener
+	analyzer *TwoPassAnalyzer
+}
+
+func (l *twoPassAnalyzerTestDeclarations) EnterEveryRule(ctx ParserRuleContext) {
+	if f, ok := ctx.(*Func_Context); ok {
+		l.analyzer.Declare(f.ID().GetText(), f)
+	}
+}
+
+// twoPassAnalyzerTestReferences resolves identifiers used in expressions.
+type twoPassAnalyzerTestReferences struct {
+	*BaseParseTreeListener
+	analyzer   *TwoPassAnalyzer
+	resolved   map[string]interface{}
+	unresolved []string
+}
+
+func (l *twoPassAnalyzerTestReferences) EnterEveryRule(ctx ParserRuleContext) {
+	if id, ok := ctx.(*IdContext); ok {
+		name := id.ID().GetText()
+		if f, ok := l.analyzer.Resolve(name); ok {
+			l.resolved[name] = f
+		} else {
+			l.unresolved = append(l.unresolved, name)
+		}
+	}
+}
+
+func TestTwoPassAnalyzerForwardReference(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { return g; } def g(y) { return h; }")
+	tree := p.Prog()
+
+	analyzer := NewTwoPassAnalyzer(tree)
+	analyzer.DeclarationPass(&twoPassAnalyzerTestDeclarations{analyzer: analyzer})
+	references := &twoPassAnalyzerTestReferences{analyzer: analyzer, resolved: make(map[string]interface{})}
+	analyzer.ResolutionPass(references)
+
+	// g is used in f before it is declared
+	g, ok := analyzer.Resolve("g")
+	assert.Equal(true, ok)
+	assert.Equal(g, references.resolved["g"])
+	assert.Equal("g", g.(*Func_Context).ID().GetText())
+	assert.Equal([]string{"h"}, references.unresolved)
+}
+
+func TestTwoPassAnalyzerRequiresDeclarations(t *testing.T) {
+	assert := assertNew(t)
+	p := newExprParserFor("def f(x) { x; }")
+	analyzer := NewTwoPassAnalyzer(p.Prog())
+
+	assert.Panics(func() {
+		analyzer.ResolutionPass(&BaseParseTreeListener{})
+	})
+}